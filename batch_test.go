@@ -0,0 +1,199 @@
+package elligator
+
+import (
+	"bytes"
+	"crypto/ecdh"
+	"crypto/rand"
+	"crypto/sha3"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func BenchmarkEncodeBatch(b *testing.B) {
+	for _, n := range []int{1, 16, 256} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			prng := sha3.NewCSHAKE128([]byte("elligator-squared-p256-benchmark"), nil)
+			points := make([][]byte, n)
+			for i := range points {
+				k, err := ecdh.P256().GenerateKey(prng)
+				if err != nil {
+					b.Fatal(err)
+				}
+				points[i] = k.PublicKey().Bytes()
+			}
+
+			for b.Loop() {
+				if _, err := EncodeBatch(points, prng); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.ReportMetric(float64(b.Elapsed().Nanoseconds())/float64(b.N*n), "ns/point")
+		})
+	}
+}
+
+func TestEncodeBatch(t *testing.T) {
+	t.Parallel()
+
+	points := make([][]byte, 100)
+	for i := range points {
+		k, err := ecdh.P256().GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		points[i] = k.PublicKey().Bytes()
+	}
+
+	encoded, err := EncodeBatch(points, rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := len(encoded), len(points); got != want {
+		t.Fatalf("len(EncodeBatch()) = %d, want = %d", got, want)
+	}
+
+	for i, b := range encoded {
+		q, err := DecodeStrict(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got, want := q, points[i]; !bytes.Equal(got, want) {
+			t.Errorf("Decode(%x) = %x, want = %x", b, got, want)
+		}
+	}
+}
+
+func TestEncodeBatchMatchesEncode(t *testing.T) {
+	t.Parallel()
+
+	// With a single point, EncodeBatch consumes the same random data in the same way as Encode.
+	k, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := k.PublicKey().Bytes()
+
+	for i := range 100 {
+		seed := fmt.Appendf(nil, "seed %d", i)
+
+		want, err := Encode(p, sha3.NewCSHAKE128(seed, nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := EncodeBatch([][]byte{p}, sha3.NewCSHAKE128(seed, nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(got[0], want) {
+			t.Errorf("EncodeBatch(%x) = %x, want = %x", p, got[0], want)
+		}
+	}
+}
+
+func TestEncodeBatchEmpty(t *testing.T) {
+	t.Parallel()
+
+	encoded, err := EncodeBatch(nil, rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(encoded) != 0 {
+		t.Errorf("EncodeBatch(nil) = %x, want = []", encoded)
+	}
+}
+
+func TestEncodeBatchInvalidPoint(t *testing.T) {
+	t.Parallel()
+
+	k, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := EncodeBatch([][]byte{k.PublicKey().Bytes(), {0}}, rand.Reader); !errors.Is(err, ErrIdentity) {
+		t.Errorf("EncodeBatch() = %v, want = %v", err, ErrIdentity)
+	}
+}
+
+func TestEncodeBatchFailures(t *testing.T) {
+	t.Parallel()
+
+	k, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A reader which always returns u = 0 never produces a valid candidate.
+	var failed *EncodingFailedError
+	if _, err := EncodeBatch([][]byte{k.PublicKey().Bytes()}, &repeatingReader{b: []byte{0}}); !errors.As(err, &failed) {
+		t.Errorf("EncodeBatch() = %v, want = %T", err, failed)
+	}
+}
+
+func BenchmarkDecodeBatch(b *testing.B) {
+	for _, n := range []int{1, 16, 256} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			prng := sha3.NewCSHAKE128([]byte("elligator-squared-p256-benchmark"), nil)
+			in := make([][]byte, n)
+			for i := range in {
+				in[i] = make([]byte, 64)
+				_, _ = prng.Read(in[i])
+			}
+
+			for b.Loop() {
+				DecodeBatch(in)
+			}
+			b.ReportMetric(float64(b.Elapsed().Nanoseconds())/float64(b.N*n), "ns/point")
+		})
+	}
+}
+
+func TestDecodeBatch(t *testing.T) {
+	t.Parallel()
+
+	in := make([][]byte, 100)
+	for i := range in {
+		in[i] = make([]byte, 64)
+		if _, err := rand.Read(in[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Mix in exceptional halves, the point at infinity, and malformed entries.
+	clear(in[3][:32])
+	clear(in[4][32:])
+	in[5] = make([]byte, 64)
+	in[6] = in[6][:63]
+	in[7] = nil
+
+	out, errs := DecodeBatch(in)
+	if got, want := len(out), len(in); got != want {
+		t.Fatalf("len(DecodeBatch()) = %d, want = %d", got, want)
+	}
+
+	for i, b := range in {
+		want, wantErr := Decode(b)
+		if !errors.Is(errs[i], wantErr) {
+			t.Errorf("DecodeBatch()[%d] error = %v, want = %v", i, errs[i], wantErr)
+		}
+
+		if !bytes.Equal(out[i], want) {
+			t.Errorf("DecodeBatch()[%d] = %x, want = %x", i, out[i], want)
+		}
+	}
+}
+
+func TestDecodeBatchEmpty(t *testing.T) {
+	t.Parallel()
+
+	out, errs := DecodeBatch(nil)
+	if len(out) != 0 || len(errs) != 0 {
+		t.Errorf("DecodeBatch(nil) = %x, %v, want = [], []", out, errs)
+	}
+}
@@ -0,0 +1,160 @@
+package elligator
+
+import (
+	"context"
+	"crypto/sha3"
+	"io"
+	"iter"
+	"runtime"
+	"sync"
+)
+
+// A BulkEncoder encodes sequences of points across GOMAXPROCS goroutines, returning the results in
+// input order.
+//
+// Each worker draws its candidates from its own cSHAKE128 stream, keyed with 32 bytes read from
+// the BulkEncoder's random source when encoding starts. With the default source those keys come
+// from crypto/rand; with a seeded WithRand reader the worker streams are derived from that seed,
+// although which worker encodes which point is not deterministic.
+//
+// A BulkEncoder is safe for concurrent use if its random source is.
+type BulkEncoder struct {
+	e       Encoder
+	workers int
+}
+
+// NewBulkEncoder returns a BulkEncoder which encodes points as an Encoder with the given options
+// would.
+func NewBulkEncoder(opts ...EncoderOption) *BulkEncoder {
+	return &BulkEncoder{e: *NewEncoder(opts...), workers: runtime.GOMAXPROCS(0)}
+}
+
+// EncodeSeq returns an iterator over the encodings of the given SEC-encoded points, in the same
+// order. A point which cannot be encoded yields a nil encoding and its error, and iteration
+// continues. If the random source fails or ctx is done, the iterator yields the error and stops.
+//
+// Stopping iteration early cancels any outstanding work. Because points is consumed in a separate
+// goroutine, stopping waits for points to yield its next value. EncodeChan does not wait for an
+// idle channel.
+func (b *BulkEncoder) EncodeSeq(ctx context.Context, points iter.Seq[[]byte]) iter.Seq2[[]byte, error] {
+	return b.encode(ctx, func(context.Context) iter.Seq[[]byte] { return points })
+}
+
+// EncodeChan is like EncodeSeq, but reads points from the given channel until it is closed.
+// Stopping iteration early does not wait for the channel to send or close.
+func (b *BulkEncoder) EncodeChan(ctx context.Context, points <-chan []byte) iter.Seq2[[]byte, error] {
+	return b.encode(ctx, func(ctx context.Context) iter.Seq[[]byte] {
+		return func(yield func([]byte) bool) {
+			for {
+				select {
+				case p, ok := <-points:
+					if !ok || !yield(p) {
+						return
+					}
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	})
+}
+
+// encode implements EncodeSeq and EncodeChan. The points are read from the sequence source returns
+// for a context which is done when encoding is cancelled or iteration stops early.
+func (b *BulkEncoder) encode(
+	ctx context.Context,
+	source func(context.Context) iter.Seq[[]byte],
+) iter.Seq2[[]byte, error] {
+	return func(yield func([]byte, error) bool) {
+		// Derive the worker streams up front, so that the random source is only read from one
+		// goroutine.
+		workers := make([]Encoder, b.workers)
+		for i := range workers {
+			var key [32]byte
+			if _, err := io.ReadFull(b.e.random(), key[:]); err != nil {
+				yield(nil, err)
+				return
+			}
+
+			xof := sha3.NewCSHAKE128(nil, []byte("elligator-squared-p256 bulk v1"))
+			_, _ = xof.Write(key[:])
+			clear(key[:])
+
+			workers[i] = b.e
+			workers[i].rand = xof
+		}
+
+		var wg sync.WaitGroup
+		defer wg.Wait()
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		// Send each point to the workers, along with a buffered channel for its result. The result
+		// channels are queued in input order.
+		jobs := make(chan bulkJob)
+		results := make(chan chan bulkResult, 2*b.workers)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer close(jobs)
+			defer close(results)
+			for p := range source(ctx) {
+				job := bulkJob{p: p, result: make(chan bulkResult, 1)}
+				select {
+				case results <- job.result:
+				case <-ctx.Done():
+					return
+				}
+
+				select {
+				case jobs <- job:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+
+		wg.Add(len(workers))
+		for i := range workers {
+			go func() {
+				defer wg.Done()
+				for job := range jobs {
+					encoded, err := workers[i].EncodeContext(ctx, job.p)
+					job.result <- bulkResult{encoded: encoded, err: err}
+				}
+			}()
+		}
+
+		for result := range results {
+			select {
+			case r := <-result:
+				if ctx.Err() != nil {
+					yield(nil, ctx.Err())
+					return
+				}
+
+				if !yield(r.encoded, r.err) {
+					return
+				}
+			case <-ctx.Done():
+				yield(nil, ctx.Err())
+				return
+			}
+		}
+
+		if err := ctx.Err(); err != nil {
+			yield(nil, err)
+		}
+	}
+}
+
+type bulkJob struct {
+	p      []byte
+	result chan bulkResult
+}
+
+type bulkResult struct {
+	encoded []byte
+	err     error
+}
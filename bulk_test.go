@@ -0,0 +1,255 @@
+package elligator
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdh"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"slices"
+	"testing"
+	"time"
+)
+
+func ExampleBulkEncoder() {
+	// Generate some P-256 ECDH public keys.
+	var points [][]byte
+	for range 10 {
+		k, err := ecdh.P256().GenerateKey(rand.Reader)
+		if err != nil {
+			panic(err)
+		}
+		points = append(points, k.PublicKey().Bytes())
+	}
+
+	// Encode them in parallel.
+	b := NewBulkEncoder()
+	i := 0
+	for encoded, err := range b.EncodeSeq(context.Background(), slices.Values(points)) {
+		if err != nil {
+			panic(err)
+		}
+
+		// Decode each public key and compare it to the original.
+		qP, err := Decode(encoded)
+		if err != nil {
+			panic(err)
+		}
+
+		if !bytes.Equal(points[i], qP) {
+			panic("mismatch")
+		}
+		i++
+	}
+
+	fmt.Println(i)
+	// Output: 10
+}
+
+func TestBulkEncoderSeq(t *testing.T) {
+	t.Parallel()
+
+	points := bulkTestPoints(t, 200)
+	got := collectBulk(t, NewBulkEncoder(WithUniform()).EncodeSeq(t.Context(), slices.Values(points)))
+	checkBulk(t, points, got)
+}
+
+func TestBulkEncoderChan(t *testing.T) {
+	t.Parallel()
+
+	points := bulkTestPoints(t, 200)
+	ch := make(chan []byte)
+	go func() {
+		defer close(ch)
+		for _, p := range points {
+			ch <- p
+		}
+	}()
+
+	got := collectBulk(t, NewBulkEncoder().EncodeChan(t.Context(), ch))
+	checkBulk(t, points, got)
+}
+
+func TestBulkEncoderInvalidPoint(t *testing.T) {
+	t.Parallel()
+
+	points := bulkTestPoints(t, 3)
+	points[1] = []byte{0}
+
+	var errs []error
+	for encoded, err := range NewBulkEncoder().EncodeSeq(t.Context(), slices.Values(points)) {
+		if (encoded == nil) == (err == nil) {
+			t.Fatalf("EncodeSeq yielded %x, %v, want exactly one of them", encoded, err)
+		}
+		errs = append(errs, err)
+	}
+
+	if got, want := len(errs), len(points); got != want {
+		t.Fatalf("EncodeSeq yielded %d results, want = %d", got, want)
+	}
+
+	if errs[0] != nil || !errors.Is(errs[1], ErrIdentity) || errs[2] != nil {
+		t.Errorf("EncodeSeq errors = %v, want = [<nil> %v <nil>]", errs, ErrIdentity)
+	}
+}
+
+func TestBulkEncoderCancelled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	var errs []error
+	for _, err := range NewBulkEncoder().EncodeSeq(ctx, slices.Values(bulkTestPoints(t, 100))) {
+		errs = append(errs, err)
+	}
+
+	if len(errs) != 1 || !errors.Is(errs[0], context.Canceled) {
+		t.Errorf("EncodeSeq errors = %v, want = [%v]", errs, context.Canceled)
+	}
+}
+
+func TestBulkEncoderCancelledChan(t *testing.T) {
+	t.Parallel()
+
+	// Cancelling the context stops iteration even if the channel is never closed.
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+	ch := make(chan []byte, 1)
+	ch <- bulkTestPoints(t, 1)[0]
+
+	var errs []error
+	for _, err := range NewBulkEncoder().EncodeChan(ctx, ch) {
+		errs = append(errs, err)
+		cancel()
+	}
+
+	if len(errs) != 2 || errs[0] != nil || !errors.Is(errs[1], context.Canceled) {
+		t.Errorf("EncodeChan errors = %v, want = [<nil> %v]", errs, context.Canceled)
+	}
+}
+
+func TestBulkEncoderBreak(t *testing.T) {
+	t.Parallel()
+
+	// Stopping iteration early stops an infinite input.
+	p := bulkTestPoints(t, 1)[0]
+	points := func(yield func([]byte) bool) {
+		for yield(p) {
+		}
+	}
+
+	n := 0
+	for _, err := range NewBulkEncoder().EncodeSeq(t.Context(), points) {
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		n++
+		if n == 10 {
+			break
+		}
+	}
+}
+
+func TestBulkEncoderBreakChan(t *testing.T) {
+	t.Parallel()
+
+	// Stopping iteration early returns even while the channel is idle and open.
+	ch := make(chan []byte, 1)
+	ch <- bulkTestPoints(t, 1)[0]
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for _, err := range NewBulkEncoder().EncodeChan(t.Context(), ch) {
+			if err != nil {
+				t.Error(err)
+			}
+			break
+		}
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("EncodeChan did not stop after break")
+	}
+}
+
+func TestBulkEncoderRandErrors(t *testing.T) {
+	t.Parallel()
+
+	var errs []error
+	b := NewBulkEncoder(WithRand(io.LimitReader(rand.Reader, 16)))
+	for _, err := range b.EncodeSeq(t.Context(), slices.Values(bulkTestPoints(t, 10))) {
+		errs = append(errs, err)
+	}
+
+	if len(errs) != 1 || !errors.Is(errs[0], io.ErrUnexpectedEOF) {
+		t.Errorf("EncodeSeq errors = %v, want = [%v]", errs, io.ErrUnexpectedEOF)
+	}
+}
+
+func TestBulkEncoderConcurrent(t *testing.T) {
+	t.Parallel()
+
+	// The same BulkEncoder can be used by several goroutines at once.
+	b := NewBulkEncoder()
+	points := bulkTestPoints(t, 50)
+	for i := range 4 {
+		t.Run(fmt.Sprintf("goroutine %d", i), func(t *testing.T) {
+			t.Parallel()
+			checkBulk(t, points, collectBulk(t, b.EncodeSeq(t.Context(), slices.Values(points))))
+		})
+	}
+}
+
+func bulkTestPoints(t *testing.T, n int) [][]byte {
+	t.Helper()
+
+	points := make([][]byte, n)
+	for i := range points {
+		k, err := ecdh.P256().GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		points[i] = k.PublicKey().Bytes()
+	}
+	return points
+}
+
+func collectBulk(t *testing.T, seq iter.Seq2[[]byte, error]) [][]byte {
+	t.Helper()
+
+	var out [][]byte
+	for encoded, err := range seq {
+		if err != nil {
+			t.Fatal(err)
+		}
+		out = append(out, encoded)
+	}
+	return out
+}
+
+func checkBulk(t *testing.T, points, encoded [][]byte) {
+	t.Helper()
+
+	if got, want := len(encoded), len(points); got != want {
+		t.Fatalf("got %d encodings, want = %d", got, want)
+	}
+
+	for i, b := range encoded {
+		q, err := Decode(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got, want := q, points[i]; !bytes.Equal(got, want) {
+			t.Errorf("Decode(%x) = %x, want = %x", b, got, want)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package elligator
+
+import (
+	"crypto/sha3"
+	"encoding/binary"
+	"math/bits"
+)
+
+// EncodeDeterministic maps the given SEC-encoded point to a 64-byte bitstring which is
+// pseudorandomly derived from the point, the seed, and the context. The same inputs always produce
+// the same output, across versions of this package.
+//
+// The candidates are drawn from cSHAKE128 (NIST SP 800-185) with an empty function name and the
+// customization string "elligator-squared-p256 deterministic v1", absorbing:
+//
+//	encode_string(seed) || encode_string(context) || P
+//
+// where P is the 65-byte uncompressed SEC encoding of the point. Each candidate consumes 33 bytes
+// of output: the first 32 bytes are a big-endian integer u, reduced mod p, and the two least
+// significant bits of the last byte are the biquadratic root index j. The first valid candidate
+// (u, r(P - f(u), j)) is returned, as with Encode.
+//
+// Because the output is a function of its inputs, it is only indistinguishable from random if the
+// seed is secret and has high entropy.
+func EncodeDeterministic(p, seed, context []byte) ([]byte, error) {
+	var x, y fieldElement
+	if err := p256SetBytes(&x, &y, p, AllFormats); err != nil {
+		return nil, err
+	}
+
+	xof := sha3.NewCSHAKE128(nil, []byte("elligator-squared-p256 deterministic v1"))
+	_, _ = xof.Write(appendEncodeString(nil, seed))
+	_, _ = xof.Write(appendEncodeString(nil, context))
+	_, _ = xof.Write(appendUncompressed(nil, &x, &y))
+
+	e := Encoder{rand: xof, attempts: DefaultMaxAttempts, formats: AllFormats}
+	return e.Encode(p)
+}
+
+// appendEncodeString appends encode_string(s) from NIST SP 800-185 to dst.
+func appendEncodeString(dst, s []byte) []byte {
+	return append(appendLeftEncode(dst, uint64(len(s))*8), s...)
+}
+
+// appendLeftEncode appends left_encode(x) from NIST SP 800-185 to dst.
+func appendLeftEncode(dst []byte, x uint64) []byte {
+	var b [9]byte
+	binary.BigEndian.PutUint64(b[1:], x)
+
+	// Trim leading zero bytes, but keep at least one byte.
+	n := max(1, 8-bits.LeadingZeros64(x)/8)
+	b[8-n] = byte(n)
+	return append(dst, b[8-n:]...)
+}
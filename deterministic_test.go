@@ -0,0 +1,108 @@
+package elligator
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"testing"
+)
+
+func TestEncodeDeterministic(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		p, seed, context, want string
+	}{
+		{
+			p:    "046b17d1f2e12c4247f8bce6e563a440f277037d812deb33a0f4a13945d898c2964fe342e2fe1a7f9b8ee7eb4a7c0f9e162bce33576b315ececbb6406837bf51f5",
+			want: "96e5c76f914fe7dc6a5a1c9e21a9ec766ebab12a7a85c787ae13f960d55c25b318f7a64ee7eeac198579c4cb5add45acb23191061ba2b2fb0bce4851356ab63c",
+		},
+		{
+			p:    "046b17d1f2e12c4247f8bce6e563a440f277037d812deb33a0f4a13945d898c2964fe342e2fe1a7f9b8ee7eb4a7c0f9e162bce33576b315ececbb6406837bf51f5",
+			seed: "seed",
+			want: "98327cf5a7a62936c8cf3a48283f0e64cead6eb108cc6d8adb2af6d41831b806e7e0a97f8c3b8d3c39e251a0e892bbbd91106bc54b961fb5adddb08332b34240",
+		},
+		{
+			p:       "046b17d1f2e12c4247f8bce6e563a440f277037d812deb33a0f4a13945d898c2964fe342e2fe1a7f9b8ee7eb4a7c0f9e162bce33576b315ececbb6406837bf51f5",
+			seed:    "seed",
+			context: "context",
+			want:    "5125217be1615b79965b92eee632d07a40af7993d7fe0cc0e0fb868fdb080dad3b32025f67d9cd14c95ee1064afef03ddf694bb7e972cfc0cc5a030bb0da4f6a",
+		},
+		{
+			// The same point in compressed form must produce the same output.
+			p:       "036b17d1f2e12c4247f8bce6e563a440f277037d812deb33a0f4a13945d898c296",
+			seed:    "seed",
+			context: "context",
+			want:    "5125217be1615b79965b92eee632d07a40af7993d7fe0cc0e0fb868fdb080dad3b32025f67d9cd14c95ee1064afef03ddf694bb7e972cfc0cc5a030bb0da4f6a",
+		},
+		{
+			p:       "041130a8d0fbc8182df8329f163d7e95a2dd8e92ae34eb1f10aee6434d30b6f3d00c04fc2f16f9c9fa1fa858e14d87632827c930495ca2d00b441f4f9139bde577",
+			seed:    "seed",
+			context: "context",
+			want:    "843c401bfb2cff02740418e7ea9a90ad637382f93bc037eb80fe7e903246ef4c756cfcc37a179641a2b4fc102a46e8943707d9d1f1cbe048a925915ab5b5e2af",
+		},
+		{
+			p:       "041130a8d0fbc8182df8329f163d7e95a2dd8e92ae34eb1f10aee6434d30b6f3d00c04fc2f16f9c9fa1fa858e14d87632827c930495ca2d00b441f4f9139bde577",
+			seed:    "another seed",
+			context: "context",
+			want:    "c609c4b6b627895fef00638435df9cc0102cca65546faad43f5abe3623797c34be95fb51d27944ae84f89651c6aae6aad2d7048eed7a13380cb11f7d3adab24d",
+		},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("EncodeDeterministic(%s, %q, %q)", test.p, test.seed, test.context), func(t *testing.T) {
+			t.Parallel()
+			p, err := hex.DecodeString(test.p)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			encoded, err := EncodeDeterministic(p, []byte(test.seed), []byte(test.context))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := hex.EncodeToString(encoded); got != test.want {
+				t.Errorf("EncodeDeterministic(%s, %q, %q) = %s, want = %s", test.p, test.seed, test.context, got, test.want)
+			}
+
+			q, err := DecodeStrict(encoded)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			c, err := DecodeCompressed(encoded)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !bytes.Equal(q, p) && !bytes.Equal(c, p) {
+				t.Errorf("Decode(%x) = %x, want = %s", encoded, q, test.p)
+			}
+		})
+	}
+}
+
+func TestLeftEncode(t *testing.T) {
+	t.Parallel()
+
+	// Examples from NIST SP 800-185, Section 2.3.1, and the encodings of lengths used above.
+	var tests = []struct {
+		x    uint64
+		want string
+	}{
+		{x: 0, want: "0100"},
+		{x: 1, want: "0101"},
+		{x: 255, want: "01ff"},
+		{x: 256, want: "020100"},
+		{x: 32, want: "0120"},
+		{x: 1 << 56, want: "080100000000000000"},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("left_encode(%d)", test.x), func(t *testing.T) {
+			t.Parallel()
+			if got := hex.EncodeToString(appendLeftEncode(nil, test.x)); got != test.want {
+				t.Errorf("left_encode(%d) = %s, want = %s", test.x, got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package elligator
+
+import (
+	"crypto/ecdh"
+	"errors"
+	"io"
+)
+
+// ErrUnsupportedCurve is returned when the given key is not on P-256.
+var ErrUnsupportedCurve = errors.New("elligator: unsupported curve")
+
+// EncodePublicKey maps the given P-256 ECDH public key to a random 64-byte bitstring. It returns
+// ErrUnsupportedCurve if the key is for any other curve.
+func EncodePublicKey(k *ecdh.PublicKey, rand io.Reader) ([]byte, error) {
+	if k.Curve() != ecdh.P256() {
+		return nil, ErrUnsupportedCurve
+	}
+	return Encode(k.Bytes(), rand)
+}
+
+// DecodePublicKey maps the Elligator Squared-encoded point to a P-256 ECDH public key.
+func DecodePublicKey(b []byte) (*ecdh.PublicKey, error) {
+	p, err := Decode(b)
+	if err != nil {
+		return nil, err
+	}
+	return ecdh.P256().NewPublicKey(p)
+}
+
+// GenerateKey generates a P-256 ECDH key pair and returns the private key along with a random
+// 64-byte encoding of its public key.
+func GenerateKey(rand io.Reader) (*ecdh.PrivateKey, []byte, error) {
+	k, err := ecdh.P256().GenerateKey(rand)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	encoded, err := EncodePublicKey(k.PublicKey(), rand)
+	if err != nil {
+		return nil, nil, err
+	}
+	return k, encoded, nil
+}
@@ -0,0 +1,78 @@
+package elligator
+
+import (
+	"crypto/ecdh"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func ExampleGenerateKey() {
+	// Generate a P-256 ECDH key pair along with an encoding of its public key.
+	k, encoded, err := GenerateKey(rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+
+	// Decode the public key.
+	pub, err := DecodePublicKey(encoded)
+	if err != nil {
+		panic(err)
+	}
+
+	// Compare the two.
+	fmt.Println(k.PublicKey().Equal(pub))
+	// Output: true
+}
+
+func TestPublicKeyRoundTrip(t *testing.T) {
+	t.Parallel()
+	for range 100 {
+		k, err := ecdh.P256().GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		encoded, err := EncodePublicKey(k.PublicKey(), rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		pub, err := DecodePublicKey(encoded)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !k.PublicKey().Equal(pub) {
+			t.Fatalf("DecodePublicKey(%x) = %x, want = %x", encoded, pub.Bytes(), k.PublicKey().Bytes())
+		}
+	}
+}
+
+func TestEncodePublicKeyUnsupportedCurve(t *testing.T) {
+	t.Parallel()
+
+	for _, curve := range []ecdh.Curve{ecdh.X25519(), ecdh.P384(), ecdh.P521()} {
+		t.Run(fmt.Sprint(curve), func(t *testing.T) {
+			t.Parallel()
+
+			k, err := curve.GenerateKey(rand.Reader)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := EncodePublicKey(k.PublicKey(), rand.Reader); !errors.Is(err, ErrUnsupportedCurve) {
+				t.Errorf("EncodePublicKey(%v) err = %v, want = %v", curve, err, ErrUnsupportedCurve)
+			}
+		})
+	}
+}
+
+func TestDecodePublicKeyIdentity(t *testing.T) {
+	t.Parallel()
+
+	if _, err := DecodePublicKey(make([]byte, 64)); !errors.Is(err, ErrIdentity) {
+		t.Errorf("DecodePublicKey() err = %v, want = %v", err, ErrIdentity)
+	}
+}
@@ -0,0 +1,42 @@
+package elligator
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"io"
+	"math/big"
+)
+
+// EncodeECDSAPublicKey maps the given P-256 ECDSA public key to a random 64-byte bitstring. It
+// returns ErrUnsupportedCurve if the key is for any other curve, and ErrInvalidPoint if the key is
+// not a valid point.
+func EncodeECDSAPublicKey(k *ecdsa.PublicKey, rand io.Reader) ([]byte, error) {
+	if k.Curve != elliptic.P256() {
+		return nil, ErrUnsupportedCurve
+	}
+
+	if k.X == nil || k.Y == nil || k.X.Sign() < 0 || k.Y.Sign() < 0 || k.X.BitLen() > 256 || k.Y.BitLen() > 256 {
+		return nil, ErrInvalidPoint
+	}
+
+	// Build the uncompressed SEC1 encoding and let Encode check that the point is on the curve.
+	var p [65]byte
+	p[0] = 4
+	k.X.FillBytes(p[1:33])
+	k.Y.FillBytes(p[33:65])
+	return Encode(p[:], rand)
+}
+
+// DecodeECDSAPublicKey maps the Elligator Squared-encoded point to a P-256 ECDSA public key.
+func DecodeECDSAPublicKey(b []byte) (*ecdsa.PublicKey, error) {
+	p, err := Decode(b)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ecdsa.PublicKey{
+		Curve: elliptic.P256(),
+		X:     new(big.Int).SetBytes(p[1:33]),
+		Y:     new(big.Int).SetBytes(p[33:65]),
+	}, nil
+}
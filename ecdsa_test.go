@@ -0,0 +1,74 @@
+package elligator
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"math/big"
+	"testing"
+)
+
+func TestECDSAPublicKeyRoundTrip(t *testing.T) {
+	t.Parallel()
+	for range 100 {
+		k, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		encoded, err := EncodeECDSAPublicKey(&k.PublicKey, rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		pub, err := DecodeECDSAPublicKey(encoded)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !k.PublicKey.Equal(pub) {
+			t.Fatalf("DecodeECDSAPublicKey(%x) != %v", encoded, k.PublicKey)
+		}
+
+		// Make sure the decoded key can verify signatures.
+		digest := sha256.Sum256(encoded)
+		sig, err := ecdsa.SignASN1(rand.Reader, k, digest[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !ecdsa.VerifyASN1(pub, digest[:], sig) {
+			t.Fatal("signature did not verify with decoded key")
+		}
+	}
+}
+
+func TestEncodeECDSAPublicKeyUnsupportedCurve(t *testing.T) {
+	t.Parallel()
+
+	k, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := EncodeECDSAPublicKey(&k.PublicKey, rand.Reader); !errors.Is(err, ErrUnsupportedCurve) {
+		t.Errorf("EncodeECDSAPublicKey() err = %v, want = %v", err, ErrUnsupportedCurve)
+	}
+}
+
+func TestEncodeECDSAPublicKeyInvalidPoint(t *testing.T) {
+	t.Parallel()
+
+	for _, k := range []*ecdsa.PublicKey{
+		{Curve: elliptic.P256(), X: big.NewInt(1), Y: big.NewInt(1)},
+		{Curve: elliptic.P256(), X: new(big.Int).Lsh(big.NewInt(1), 256), Y: big.NewInt(1)},
+		{Curve: elliptic.P256(), X: big.NewInt(-1), Y: big.NewInt(1)},
+		{Curve: elliptic.P256()},
+	} {
+		if _, err := EncodeECDSAPublicKey(k, rand.Reader); !errors.Is(err, ErrInvalidPoint) {
+			t.Errorf("EncodeECDSAPublicKey(%v) err = %v, want = %v", k, err, ErrInvalidPoint)
+		}
+	}
+}
@@ -1,198 +1,381 @@
 package elligator
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"io"
+	"slices"
+
+	"github.com/codahale/elligator-squared-p256/internal/p256"
 )
 
 var (
 	// ErrInvalidEncoding is returned when the given encoded point is malformed.
 	ErrInvalidEncoding = errors.New("elligator: invalid encoding")
-	// ErrInvalidPoint is returned when the given point is not an uncompressed SEC point.
+	// ErrInvalidPoint is returned when the given point is not a valid SEC-encoded P-256 point.
 	ErrInvalidPoint = errors.New("elligator: invalid point")
+	// ErrEncodingFailed is returned when no valid encoding was found within the allotted attempts.
+	ErrEncodingFailed = errors.New("elligator: encoding failed")
+	// ErrIdentity is returned when the given encoded point maps to the point at infinity.
+	ErrIdentity = errors.New("elligator: point at infinity")
+	// ErrInvalidAttempts is returned when the given number of attempts is out of range.
+	ErrInvalidAttempts = errors.New("elligator: invalid number of attempts")
 )
 
-// Decode maps the Elligator Squared-encoded point to an uncompressed SEC-encoded point.
+// Decode maps the Elligator Squared-encoded point to an uncompressed SEC-encoded point. Each half of
+// the encoding is reduced mod p, so the lifted encodings produced by EncodeUniform are accepted. It
+// returns ErrIdentity if the encoding maps to the point at infinity.
 func Decode(b []byte) ([]byte, error) {
+	return AppendDecode(nil, b)
+}
+
+// AppendDecode is like Decode, but appends the uncompressed SEC-encoded point to dst and returns the
+// extended buffer. It does not allocate if dst has sufficient capacity.
+func AppendDecode(dst, b []byte) ([]byte, error) {
+	var x, y fieldElement
+	if err := decodeBytes(&x, &y, b); err != nil {
+		return dst, err
+	}
+	return appendUncompressed(dst, &x, &y), nil
+}
+
+// DecodeCompressed is like Decode, but returns a 33-byte compressed SEC-encoded point.
+func DecodeCompressed(b []byte) ([]byte, error) {
+	var x, y fieldElement
+	if err := decodeBytes(&x, &y, b); err != nil {
+		return nil, err
+	}
+	return p256.AppendCompressed(nil, &x, &y), nil
+}
+
+// DecodeXY is like Decode, but returns the affine coordinates of the point as 32-byte big-endian
+// integers.
+func DecodeXY(b []byte) (x, y []byte, err error) {
+	var px, py fieldElement
+	if err := decodeBytes(&px, &py, b); err != nil {
+		return nil, nil, err
+	}
+	return px.Bytes(), py.Bytes(), nil
+}
+
+// DecodeStrict maps the Elligator Squared-encoded point to an uncompressed SEC-encoded point,
+// accepting only the canonical encodings produced by Encode, EncodeContext, and
+// EncodeConstantTime.
+//
+// Unlike Decode, it returns ErrInvalidEncoding if either half of the encoding is not less than p or
+// is in {-1, 0, 1}, which makes each representative unique. It returns ErrIdentity if the encoding
+// maps to the point at infinity.
+func DecodeStrict(b []byte) ([]byte, error) {
 	if len(b) != 64 {
 		return nil, ErrInvalidEncoding
 	}
 
-	// p = f(u) + f(v)
-	u, v := new(fieldElement).SetBytes(b[:32]), new(fieldElement).SetBytes(b[32:])
-	x1, y1 := f(u)
-	x2, y2 := f(v)
-	x3, y3 := p256Add(x1, y1, x2, y2)
+	var u, v, x, y fieldElement
+	ok := u.SetCanonicalBytes((*[32]byte)(b[:32])) & v.SetCanonicalBytes((*[32]byte)(b[32:]))
+	ok &^= isExceptional(&u) | isExceptional(&v)
+	if ok != 1 {
+		return nil, ErrInvalidEncoding
+	}
 
-	var out [65]byte
-	out[0] = 4
-	copy(out[1:33], x3.Bytes())
-	copy(out[33:], y3.Bytes())
-	return out[:], nil
+	decode(&x, &y, &u, &v)
+	if x.IsZero()&y.IsZero() == 1 {
+		return nil, ErrIdentity
+	}
+	return appendUncompressed(nil, &x, &y), nil
 }
 
-// Encode maps the given uncompressed SEC-encoded point to a random 64-byte bitstring.
+// decodeBytes sets (x, y) to the point encoded by b, reducing each half mod p.
+func decodeBytes(x, y *fieldElement, b []byte) error {
+	if len(b) != 64 {
+		return ErrInvalidEncoding
+	}
+
+	var u, v fieldElement
+	u.SetBytes((*[32]byte)(b[:32]))
+	v.SetBytes((*[32]byte)(b[32:]))
+	decode(x, y, &u, &v)
+	if x.IsZero()&y.IsZero() == 1 {
+		return ErrIdentity
+	}
+	return nil
+}
+
+// decode sets (x, y) to f(u) + f(v).
+func decode(x, y, u, v *fieldElement) {
+	var x2, y2 fieldElement
+	f(x, y, u)
+	f(&x2, &y2, v)
+	p256.Add(x, y, x, y, &x2, &y2)
+}
+
+// appendUncompressed appends the uncompressed SEC encoding of (x, y) to dst.
+func appendUncompressed(dst []byte, x, y *fieldElement) []byte {
+	var buf [32]byte
+	dst = slices.Grow(dst, 65)
+	dst = append(dst, 4)
+	dst = append(dst, x.FillBytes(&buf)...)
+	return append(dst, y.FillBytes(&buf)...)
+}
+
+// DefaultMaxAttempts is the number of candidates Encode tries before giving up. Each candidate
+// succeeds with probability roughly 1/4, so exhausting it is only plausible with a broken RNG.
+const DefaultMaxAttempts = 1_000
+
+// EncodingFailedError is returned when no valid encoding was found within the allotted attempts.
+// It wraps ErrEncodingFailed.
+type EncodingFailedError struct {
+	// Attempts is the number of candidates which were tried.
+	Attempts int
+}
+
+func (e *EncodingFailedError) Error() string {
+	return fmt.Sprintf("elligator: encoding failed after %d attempts", e.Attempts)
+}
+
+func (e *EncodingFailedError) Unwrap() error {
+	return ErrEncodingFailed
+}
+
+// Encode maps the given SEC-encoded point to a random 64-byte bitstring, trying at most
+// DefaultMaxAttempts candidates.
+//
+// The point may be in the compressed, uncompressed, or hybrid format. Encode returns
+// ErrInvalidPoint if it is malformed or not on the curve, and ErrIdentity if it is the point at
+// infinity, which cannot be encoded.
+//
+// Encode runs in variable time: the number of candidates it tries before succeeding depends on the
+// input point. Use EncodeConstantTime if that is a concern.
 func Encode(p []byte, rand io.Reader) ([]byte, error) {
-	zero := new(fieldElement)
+	return AppendEncode(nil, p, rand)
+}
+
+// AppendEncode is like Encode, but appends the 64-byte bitstring to dst and returns the extended
+// buffer. It does not allocate if dst has at least 64 bytes of spare capacity.
+func AppendEncode(dst, p []byte, rand io.Reader) ([]byte, error) {
+	e := Encoder{rand: rand, attempts: DefaultMaxAttempts, formats: AllFormats}
+	return e.appendEncode(context.Background(), dst, p)
+}
 
-	if len(p) != 65 || p[0] != 4 {
-		return nil, ErrInvalidPoint
+// EncodeContext maps the given SEC-encoded point to a random 64-byte bitstring, trying at most
+// maxAttempts candidates. If no candidate succeeds, it returns an *EncodingFailedError. If ctx is
+// done before a candidate succeeds, it returns ctx.Err(). It returns an error wrapping
+// ErrInvalidAttempts if maxAttempts is less than 1.
+func EncodeContext(ctx context.Context, p []byte, rand io.Reader, maxAttempts int) ([]byte, error) {
+	if maxAttempts < 1 {
+		return nil, fmt.Errorf("%w: maxAttempts must be at least 1, got %d", ErrInvalidAttempts, maxAttempts)
 	}
 
-	var buf [64]byte
-	for range 1_000 {
-		// Generate a random field element \not\in {-1, 0, 1}.
-		if _, err := io.ReadFull(rand, buf[:32]); err != nil {
-			return nil, err
-		}
-		u := new(fieldElement).SetBytes(buf[:32])
-		if u.CmpAbs(new(fieldElement).SetInt64(1)) == 0 || u.CmpAbs(zero) == 0 {
-			continue
-		}
-
-		// Map the field element to a point and calculate the difference between the random point
-		// and the input point: q = p - f(u).
-		x1, y1 := new(fieldElement).SetBytes(p[1:33]), new(fieldElement).SetBytes(p[33:])
-		x2, y2 := f(u)
-		y2.Neg(y2)
-		x3, y3 := p256Add(x1, y1, x2, y2)
-
-		// If we managed to randomly generate -p, congratulate ourselves on the improbable and keep
-		// trying.
-		if x3.Cmp(zero) == 0 && y3.Cmp(zero) == 0 {
-			continue
-		}
-
-		// Pick a random biquadratic root from [0,4).
-		if _, err := io.ReadFull(rand, buf[:1]); err != nil {
-			return nil, err
-		}
-		j := buf[0] % 4
-
-		// If the Jth biquadratic root exists for the delta point, return our random field element
-		// and our preimage field element: f(v) = q.
-		v := r(x3, y3, j)
-		if v != nil {
-			copy(buf[:32], u.Bytes())
-			copy(buf[32:], v.Bytes())
-			return buf[:], nil
-		}
+	e := Encoder{rand: rand, attempts: maxAttempts, formats: AllFormats}
+	return e.appendEncode(ctx, nil, p)
+}
+
+// EncodeConstantTime maps the given SEC-encoded point to a random 64-byte bitstring in time
+// independent of the point.
+//
+// Unlike Encode, it always evaluates exactly the given number of candidates, selecting the first
+// successful one with constant-time conditional moves. Each candidate succeeds with probability
+// roughly 1/4, so EncodeConstantTime fails with probability roughly (3/4)^attempts; e.g., 64
+// attempts fail with probability ~2^-26, and 128 attempts fail with probability ~2^-53. If no
+// candidate succeeds, it returns an *EncodingFailedError. It returns an error wrapping
+// ErrInvalidAttempts if attempts is less than 1.
+func EncodeConstantTime(p []byte, rand io.Reader, attempts int) ([]byte, error) {
+	if attempts < 1 {
+		return nil, fmt.Errorf("%w: attempts must be at least 1, got %d", ErrInvalidAttempts, attempts)
 	}
 
-	panic("elliqator: failed to find candidate, suspect RNG failure")
+	e := Encoder{rand: rand, attempts: attempts, constantTime: true, formats: AllFormats}
+	return e.appendEncode(context.Background(), nil, p)
 }
 
-func f(u *fieldElement) (x, y *fieldElement) {
+// EncodeUniform maps the given SEC-encoded point to a random 64-byte bitstring which is uniformly
+// distributed over all 2^512 bitstrings, trying at most DefaultMaxAttempts candidates.
+//
+// Encode produces halves which are always less than p, which is distinguishable from random with
+// enough samples. EncodeUniform instead lifts each half into [0, 2^256) by randomly adding p. The
+// output can be decoded with Decode. Each candidate succeeds with probability roughly 1/8.
+func EncodeUniform(p []byte, rand io.Reader) ([]byte, error) {
+	e := Encoder{rand: rand, attempts: DefaultMaxAttempts, uniform: true, formats: AllFormats}
+	return e.appendEncode(context.Background(), nil, p)
+}
+
+// Rerandomize maps the given 64-byte bitstring to a fresh random 64-byte bitstring which decodes to
+// the same point, without round-tripping through a SEC encoding. The output is independent of the
+// input beyond the point they share. It returns ErrInvalidEncoding if b is not 64 bytes long and
+// ErrIdentity if b decodes to the point at infinity.
+func Rerandomize(b []byte, rand io.Reader) ([]byte, error) {
+	var x, y fieldElement
+	if err := decodeBytes(&x, &y, b); err != nil {
+		return nil, err
+	}
+
+	e := Encoder{rand: rand, attempts: DefaultMaxAttempts, formats: AllFormats}
+	return e.appendEncodePoint(context.Background(), nil, &x, &y)
+}
+
+// candidate sets v to the jth preimage of p - f(u) under f, and returns 1 if (u, v) is a valid
+// encoding of p and 0 otherwise. It runs in constant time.
+func candidate(v, px, py, u *fieldElement, j byte) int {
+	// Reject random field elements \in {-1, 0, 1}.
+	ok := 1 ^ isExceptional(u)
+
+	// Map the field element to a point and calculate the difference between the random point
+	// and the input point: q = p - f(u).
+	var x, y fieldElement
+	f(&x, &y, u)
+	y.Neg(&y)
+	p256.Add(&x, &y, px, py, &x, &y)
+
+	// If we managed to randomly generate -p, congratulate ourselves on the improbable and keep
+	// trying.
+	ok &= 1 ^ (x.IsZero() & y.IsZero())
+
+	// The candidate is valid if the jth biquadratic root exists for the delta point: f(v) = q.
+	return ok & r(v, &x, &y, j)
+}
+
+// f sets (x, y) to the point u maps to. It runs in constant time. The outputs may overlap with u.
+func f(x, y, u *fieldElement) {
+	var d fieldElement
+	d.Invert(denominator(&d, u))
+	fInverted(x, y, u, &d)
+}
+
+// fInverted is like f, but takes d = 1/(u^4 - u^2) so that callers can batch the inversion. The
+// outputs may overlap with u or d.
+func fInverted(x, y, u, d *fieldElement) {
 	// Case 1: u \in {-1, 0, 1}
 	// return: infinity
-	zero := new(fieldElement)
-	if u.CmpAbs(new(fieldElement).SetInt64(1)) == 0 || u.Cmp(zero) == 0 {
-		return zero, zero
-	}
+	exceptional := isExceptional(u)
 
 	// Case 2: u \not\in {-1, 0, 1} and g(X_0(u)) is a square
 	// return: (X_0(u), \sqrt{g(X_0(u))})
-	x = x0(u)
-	y = new(fieldElement).Sqrt(g(x))
-	if y != nil {
-		return x, y
-	}
+	var xa, ya fieldElement
+	x0Inverted(&xa, d)
+	isSquare := ya.SqrtCandidate(p256.G(&ya, &xa))
 
 	// Case 3: u \not\in {-1, 0, 1} and g(X_0(u)) is not a square
-	// return: (X_1(u), -\sqrt{g(X_1(u))})
-	x = x1(u)
-	y = new(fieldElement).Sqrt(g(x))
-	if y == nil {
-		panic("feSqrt(g(x)) returned nil")
-	}
-	y.Neg(y)
-	return x, y
+	// return: (X_1(u), -\sqrt{g(X_1(u))}), where X_1(u) = -u^2 X_0(u)
+	var xb, yb fieldElement
+	xb.Square(u)
+	xb.Neg(&xb)
+	xb.Mul(&xb, &xa)
+	yb.SqrtCandidate(p256.G(&yb, &xb))
+	yb.Neg(&yb)
+
+	var zero fieldElement
+	x.Select(&xa, &xb, isSquare)
+	y.Select(&ya, &yb, isSquare)
+	x.Select(&zero, x, exceptional)
+	y.Select(&zero, y, exceptional)
+}
+
+// r sets e to the jth preimage of (x, y) under f, and returns 1 if that preimage exists and 0
+// otherwise. It runs in constant time. e may overlap with x or y.
+func r(e, x, y *fieldElement, j byte) int {
+	var omega, a, b fieldElement
+	ok := rDivisor(&omega, &a, &b, x, y, j)
+	b.Invert(&b)
+	return ok & rInverted(e, &omega, &a, &b, j)
 }
 
-func r(x, y *fieldElement, j byte) *fieldElement {
+// rDivisor computes the values omega and a shared by the preimages of (x, y) under f, along with
+// the divisor b which r must invert. It returns 1 if the first square root exists and 0 otherwise.
+// It runs in constant time.
+func rDivisor(omega, a, b, x, y *fieldElement, j byte) int {
+	ok := rOmega(omega, a, x)
+	rSelect(a, b, omega, y, j)
+	return ok
+}
+
+// rOmega sets omega = (A/B) x + 1 and a = \sqrt{\omega^2 - 4 \omega}, the first square root shared by
+// the preimages of (x, y) under f. It returns 1 if the square root exists and 0 otherwise. It runs
+// in constant time.
+func rOmega(omega, a, x *fieldElement) int {
 	// Inverting `f` requires two branches, one for X_0 and one for X_1, each of which has four
 	// roots. omega is constant across all of them.
-	omega := new(fieldElement).SetB()
-	omega.Invert(omega)
-	omega.Mul(omega, new(fieldElement).SetA())
+	omega.SetAOverB()
 	omega.Mul(omega, x)
-	omega.Add(omega, new(fieldElement).SetInt64(1))
-
-	omega2 := new(fieldElement).Exp(omega, 2)
-	fourOmega := new(fieldElement).Mul(omega, new(fieldElement).SetInt64(4))
-	omega2Sub4Omega := new(fieldElement).Sub(omega2, fourOmega)
-
-	a := new(fieldElement).Sqrt(omega2Sub4Omega)
-	if a == nil {
-		return nil
-	}
+	omega.Add(omega, new(fieldElement).One())
+
+	var fourOmega fieldElement
+	a.Square(omega)
+	fourOmega.Add(omega, omega)
+	fourOmega.Add(&fourOmega, &fourOmega)
+	a.Sub(a, &fourOmega)
+	return a.SqrtCandidate(a)
+}
 
+// rSelect negates a as the jth preimage requires, and sets b to the divisor which r must invert.
+// It runs in constant time.
+func rSelect(a, b, omega, y *fieldElement, j byte) {
 	// The first division in roots comes at \sqrt{\omega^2 - 4 \omega}. The first and second
 	// roots have positive values, the third and fourth roots have negative values.
-	if j == 2 || j == 3 {
-		a.Neg(a)
-	}
-
-	// If g(x) is square, then, x=X_0(u); otherwise x=X_1(u).
-	var b = new(fieldElement)
-	if new(fieldElement).Sqrt(y) != nil {
-		// If x=X_0(u), then we divide by 2 \omega.
-		b.Mul(new(fieldElement).SetInt64(2), omega)
-		b.Invert(b)
-	} else {
-		// If x=X_1(u), then we divide by 2.
-		b.Invert(new(fieldElement).SetInt64(2))
-	}
+	var negA fieldElement
+	negA.Neg(a)
+	a.Select(&negA, a, int(j>>1)&1)
+
+	// If g(x) is square, then, x=X_0(u); otherwise x=X_1(u). If x=X_0(u), then we divide by
+	// 2 \omega; if x=X_1(u), then we divide by 2.
+	var two, twoOmega fieldElement
+	two.SetInt64(2)
+	twoOmega.Add(omega, omega)
+	b.Select(&twoOmega, &two, new(fieldElement).SqrtCandidate(y))
+}
 
-	c := new(fieldElement).Add(omega, a)
-	c.Mul(c, b)
-	if c.Sqrt(c) == nil {
-		return nil
-	}
+// rInverted sets e to the jth preimage given the omega and a computed by rDivisor and the inverse of
+// its divisor b. It returns 1 if the second square root exists and 0 otherwise. It runs in constant
+// time.
+func rInverted(e, omega, a, bInv *fieldElement, j byte) int {
+	var c fieldElement
+	c.Add(omega, a)
+	c.Mul(&c, bInv)
+	ok := c.SqrtCandidate(&c)
 
 	// The second division in roots comes here. The first and third roots have positive
 	// values, the second and fourth roots have negative values.
-	if j == 1 || j == 3 {
-		c.Neg(c)
-	}
+	var negC fieldElement
+	negC.Neg(&c)
+	e.Select(&negC, &c, int(j)&1)
 
-	return c
+	return ok
 }
 
-func g(x *fieldElement) *fieldElement {
-	// x^3
-	y := new(fieldElement).Exp(x, 3)
-
-	// -3x
-	y.Sub(y, x)
-	y.Sub(y, x)
-	y.Sub(y, x)
-
-	// B
-	y.Add(y, new(fieldElement).SetB())
-
-	return y
+// x0 sets e to X_0(u) = -B/A (1 + 1/(u^4 - u^2)), and returns e. e may overlap with u.
+func x0(e, u *fieldElement) *fieldElement {
+	var d fieldElement
+	d.Invert(denominator(&d, u))
+	return x0Inverted(e, &d)
 }
 
-func x0(u *fieldElement) *fieldElement {
-	u2 := new(fieldElement).Exp(u, 2)
-	b := new(fieldElement).Exp(u2, 2)
-	b.Sub(b, u2)
-	b.Invert(b)
-	b.Add(b, new(fieldElement).SetInt64(1))
+// x0Inverted sets e to X_0(u) = -B/A (1 + d), where d = 1/(u^4 - u^2), and returns e. e may
+// overlap with d.
+func x0Inverted(e, d *fieldElement) *fieldElement {
+	var b fieldElement
+	b.Add(d, new(fieldElement).One())
+	return e.Mul(new(fieldElement).SetNegBOverA(), &b)
+}
 
-	a := new(fieldElement).SetA()
-	a.Invert(a)
-	a.Mul(a, new(fieldElement).SetB())
-	a.Neg(a)
-	b.Mul(a, b)
+// denominator sets e to u^4 - u^2, and returns e. e may overlap with u.
+func denominator(e, u *fieldElement) *fieldElement {
+	var u2 fieldElement
+	u2.Square(u)
+	e.Square(&u2)
+	return e.Sub(e, &u2)
+}
 
-	return b
+// x1 sets e to X_1(u) = -u^2 X_0(u), and returns e. e may overlap with u.
+func x1(e, u *fieldElement) *fieldElement {
+	var y fieldElement
+	y.Square(u)
+	y.Neg(&y)
+	return e.Mul(&y, x0(new(fieldElement), u))
 }
 
-func x1(u *fieldElement) *fieldElement {
-	y := new(fieldElement).Exp(u, 2)
-	y.Neg(y)
-	y.Mul(y, x0(u))
-	return y
+// isExceptional returns 1 if u \in {-1, 0, 1}, and 0 otherwise.
+func isExceptional(u *fieldElement) int {
+	var one, minusOne fieldElement
+	one.One()
+	minusOne.Neg(&one)
+	return u.IsZero() | u.Equal(&one) | u.Equal(&minusOne)
 }
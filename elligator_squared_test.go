@@ -2,12 +2,19 @@ package elligator
 
 import (
 	"bytes"
+	"context"
 	"crypto/ecdh"
 	"crypto/rand"
 	"crypto/sha3"
 	"encoding/hex"
+	"errors"
 	"fmt"
+	"io"
+	"math"
+	"strings"
 	"testing"
+
+	"github.com/codahale/elligator-squared-p256/internal/p256"
 )
 
 func Example() {
@@ -51,6 +58,23 @@ func BenchmarkEncode(b *testing.B) {
 	}
 }
 
+func BenchmarkEncodeConstantTime(b *testing.B) {
+	// Use CSHAKE128 as a deterministic source of "random" data to allow for deterministic benchmarking.
+	prng := sha3.NewCSHAKE128([]byte("elligator-squared-p256-benchmark"), nil)
+
+	k, err := ecdh.P256().GenerateKey(prng)
+	if err != nil {
+		b.Fatal(err)
+	}
+	p := k.PublicKey().Bytes()
+
+	for b.Loop() {
+		if _, err := EncodeConstantTime(p, prng, 64); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
 func BenchmarkDecode(b *testing.B) {
 	e, err := hex.DecodeString("d63d2829acfae73ecf9ba818dfd0431fd1ba6c459d54db40bc5500220268e6279ac94968d2c32fe46e1ca3db1dba72b86eafa0857865c01fe63d62b718789e80")
 	if err != nil {
@@ -64,6 +88,133 @@ func BenchmarkDecode(b *testing.B) {
 	}
 }
 
+func BenchmarkAppendEncode(b *testing.B) {
+	// Use CSHAKE128 as a deterministic source of "random" data to allow for deterministic benchmarking.
+	prng := sha3.NewCSHAKE128([]byte("elligator-squared-p256-benchmark"), nil)
+
+	k, err := ecdh.P256().GenerateKey(prng)
+	if err != nil {
+		b.Fatal(err)
+	}
+	p := k.PublicKey().Bytes()
+	dst := make([]byte, 0, 128)
+
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := AppendEncode(dst, p, prng); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkAppendDecode(b *testing.B) {
+	e, err := hex.DecodeString("d63d2829acfae73ecf9ba818dfd0431fd1ba6c459d54db40bc5500220268e6279ac94968d2c32fe46e1ca3db1dba72b86eafa0857865c01fe63d62b718789e80")
+	if err != nil {
+		b.Fatal(err)
+	}
+	dst := make([]byte, 0, 65)
+
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := AppendDecode(dst, e); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestAppend(t *testing.T) {
+	t.Parallel()
+
+	k, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := k.PublicKey().Bytes()
+
+	prefix := []byte("prefix")
+	encoded, err := AppendEncode(prefix, p, rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := len(encoded), len(prefix)+64; got != want {
+		t.Fatalf("len(AppendEncode()) = %d, want = %d", got, want)
+	}
+
+	if got, want := encoded[:len(prefix)], prefix; !bytes.Equal(got, want) {
+		t.Fatalf("AppendEncode() prefix = %q, want = %q", got, want)
+	}
+
+	decoded, err := AppendDecode(prefix, encoded[len(prefix):])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := decoded, append(prefix, p...); !bytes.Equal(got, want) {
+		t.Fatalf("AppendDecode() = %x, want = %x", got, want)
+	}
+}
+
+func TestAppendEncodeSpareCapacity(t *testing.T) {
+	t.Parallel()
+
+	k, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := k.PublicKey().Bytes()
+
+	buf := bytes.Repeat([]byte{0xaa}, 128)
+	encoded, err := AppendEncode(buf[:3], p, rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := buf[len(encoded):], bytes.Repeat([]byte{0xaa}, 128-len(encoded)); !bytes.Equal(got, want) {
+		t.Errorf("AppendEncode() wrote past the returned length: %x", got)
+	}
+
+	// A failed encoding must not leave random bytes behind in the spare capacity.
+	buf = bytes.Repeat([]byte{0xaa}, 128)
+	r := io.LimitReader(&repeatingReader{b: []byte{0xbb}}, 16)
+	if _, err := AppendEncode(buf[:3], p, r); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatalf("AppendEncode() err = %v, want = %v", err, io.ErrUnexpectedEOF)
+	}
+
+	if i := bytes.IndexByte(buf, 0xbb); i >= 0 {
+		t.Errorf("AppendEncode() left random bytes in the spare capacity: %x", buf[3:])
+	}
+}
+
+func TestAppendAllocations(t *testing.T) {
+	k, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := k.PublicKey().Bytes()
+	encoded, err := Encode(p, rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dst := make([]byte, 0, 128)
+	if allocs := testing.AllocsPerRun(100, func() {
+		if _, err := AppendEncode(dst, p, rand.Reader); err != nil {
+			t.Fatal(err)
+		}
+	}); allocs > 0 {
+		t.Errorf("AppendEncode allocs = %v, want = 0", allocs)
+	}
+
+	if allocs := testing.AllocsPerRun(100, func() {
+		if _, err := AppendDecode(dst, encoded); err != nil {
+			t.Fatal(err)
+		}
+	}); allocs > 0 {
+		t.Errorf("AppendDecode allocs = %v, want = 0", allocs)
+	}
+}
+
 func TestRoundTrip(t *testing.T) {
 	t.Parallel()
 	for range 1_000 {
@@ -88,43 +239,569 @@ func TestRoundTrip(t *testing.T) {
 	}
 }
 
-func TestG(t *testing.T) {
+func TestEncodeFormats(t *testing.T) {
 	t.Parallel()
 
 	var tests = []struct {
-		x, want *fieldElement
+		name, p, want string
 	}{
 		{
-			x:    new(fieldElement).SetInt64(1),
-			want: new(fieldElement).SetString("5ac635d8aa3a93e7b3ebbd55769886bc651d06b0cc53b0f63bce3c3e27d26049"),
-		},
-		{
-			x:    new(fieldElement).SetInt64(2),
-			want: new(fieldElement).SetString("5ac635d8aa3a93e7b3ebbd55769886bc651d06b0cc53b0f63bce3c3e27d2604d"),
+			name: "uncompressed",
+			p:    "046b17d1f2e12c4247f8bce6e563a440f277037d812deb33a0f4a13945d898c2964fe342e2fe1a7f9b8ee7eb4a7c0f9e162bce33576b315ececbb6406837bf51f5",
+			want: "046b17d1f2e12c4247f8bce6e563a440f277037d812deb33a0f4a13945d898c2964fe342e2fe1a7f9b8ee7eb4a7c0f9e162bce33576b315ececbb6406837bf51f5",
 		},
 		{
-			x:    new(fieldElement).SetString("4077f2bde92bfa027151a7412d6e92ba0c035eb58dc8c86b4f659536c36b47d5"),
-			want: new(fieldElement).SetString("2819ec852c134ff7a481d7adbc3f1a085bc9f6b250a5917a822703f191f3ea4d"),
+			name: "compressed",
+			p:    "036b17d1f2e12c4247f8bce6e563a440f277037d812deb33a0f4a13945d898c296",
+			want: "046b17d1f2e12c4247f8bce6e563a440f277037d812deb33a0f4a13945d898c2964fe342e2fe1a7f9b8ee7eb4a7c0f9e162bce33576b315ececbb6406837bf51f5",
 		},
 		{
-			x:    new(fieldElement).SetString("dbf9ace2b5d50a2974d1227c37571235055b3ceccc5b075d0a7dccb571a0e497"),
-			want: new(fieldElement).SetString("72bde2e2f464bbcb043d01e6901f8949b90a9167775cf278990a1a31d321a691"),
+			name: "hybrid",
+			p:    "076b17d1f2e12c4247f8bce6e563a440f277037d812deb33a0f4a13945d898c2964fe342e2fe1a7f9b8ee7eb4a7c0f9e162bce33576b315ececbb6406837bf51f5",
+			want: "046b17d1f2e12c4247f8bce6e563a440f277037d812deb33a0f4a13945d898c2964fe342e2fe1a7f9b8ee7eb4a7c0f9e162bce33576b315ececbb6406837bf51f5",
 		},
 		{
-			x:    new(fieldElement).SetString("49a25b63783bc98313dc9590892d74a4e6ef2daac04910d9a84ba0c45f62ba37"),
-			want: new(fieldElement).SetString("58e94d66dc3b8bb3a6e07fa7998e38084b9a374eb4da29b70d26f7c77531b287"),
+			name: "compressed x = 0",
+			p:    "020000000000000000000000000000000000000000000000000000000000000000",
+			want: "040000000000000000000000000000000000000000000000000000000000000000" +
+				"66485c780e2f83d72433bd5d84a06bb6541c2af31dae871728bf856a174f93f4",
 		},
 	}
 	for _, test := range tests {
-		t.Run(fmt.Sprintf("g(%s)", test.x), func(t *testing.T) {
+		t.Run(test.name, func(t *testing.T) {
 			t.Parallel()
-			if got, want := g(test.x), test.want; got.Cmp(want) != 0 {
-				t.Errorf("g(%s) = %s, want = %s", test.x, got, want)
+			p, err := hex.DecodeString(test.p)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			encoded, err := Encode(p, rand.Reader)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			q, err := Decode(encoded)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := hex.EncodeToString(q); got != test.want {
+				t.Errorf("Decode(Encode(%s)) = %s, want = %s", test.p, got, test.want)
 			}
 		})
 	}
 }
 
+func TestEncodeInvalidPoints(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		name, p string
+		err     error
+	}{
+		{name: "empty", p: "", err: ErrInvalidPoint},
+		{name: "identity", p: "00", err: ErrIdentity},
+		{name: "unknown format", p: "056b17d1f2e12c4247f8bce6e563a440f277037d812deb33a0f4a13945d898c2964fe342e2fe1a7f9b8ee7eb4a7c0f9e162bce33576b315ececbb6406837bf51f5", err: ErrInvalidPoint},
+		{name: "uncompressed truncated", p: "046b17d1f2e12c4247f8bce6e563a440f277037d812deb33a0f4a13945d898c2964fe342e2fe1a7f9b8ee7eb4a7c0f9e162bce33576b315ececbb6406837bf51", err: ErrInvalidPoint},
+		{name: "uncompressed off curve", p: "046b17d1f2e12c4247f8bce6e563a440f277037d812deb33a0f4a13945d898c2964fe342e2fe1a7f9b8ee7eb4a7c0f9e162bce33576b315ececbb6406837bf51f6", err: ErrInvalidPoint},
+		{name: "uncompressed zero", p: "04" + strings.Repeat("00", 64), err: ErrInvalidPoint},
+		{name: "uncompressed x = p", p: "04ffffffff00000001000000000000000000000000ffffffffffffffffffffffff4fe342e2fe1a7f9b8ee7eb4a7c0f9e162bce33576b315ececbb6406837bf51f5", err: ErrInvalidPoint},
+		{name: "uncompressed y = p", p: "046b17d1f2e12c4247f8bce6e563a440f277037d812deb33a0f4a13945d898c296ffffffff00000001000000000000000000000000ffffffffffffffffffffffff", err: ErrInvalidPoint},
+		{name: "compressed truncated", p: "036b17d1f2e12c4247f8bce6e563a440f277037d812deb33a0f4a13945d898c2", err: ErrInvalidPoint},
+		{name: "compressed x = p", p: "02ffffffff00000001000000000000000000000000ffffffffffffffffffffffff", err: ErrInvalidPoint},
+		{name: "compressed not on curve", p: "02" + strings.Repeat("00", 31) + "01", err: ErrInvalidPoint},
+		{name: "compressed with uncompressed length", p: "026b17d1f2e12c4247f8bce6e563a440f277037d812deb33a0f4a13945d898c2964fe342e2fe1a7f9b8ee7eb4a7c0f9e162bce33576b315ececbb6406837bf51f5", err: ErrInvalidPoint},
+		{name: "hybrid wrong parity", p: "066b17d1f2e12c4247f8bce6e563a440f277037d812deb33a0f4a13945d898c2964fe342e2fe1a7f9b8ee7eb4a7c0f9e162bce33576b315ececbb6406837bf51f5", err: ErrInvalidPoint},
+		{name: "hybrid off curve", p: "076b17d1f2e12c4247f8bce6e563a440f277037d812deb33a0f4a13945d898c2964fe342e2fe1a7f9b8ee7eb4a7c0f9e162bce33576b315ececbb6406837bf51f6", err: ErrInvalidPoint},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			p, err := hex.DecodeString(test.p)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := Encode(p, rand.Reader); !errors.Is(err, test.err) {
+				t.Errorf("Encode(%s) err = %v, want = %v", test.p, err, test.err)
+			}
+		})
+	}
+}
+
+func TestEncodeFailures(t *testing.T) {
+	t.Parallel()
+
+	k, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := k.PublicKey().Bytes()
+
+	// A repeating reader which always produces u = 1, which is never a valid candidate.
+	one := make([]byte, 33)
+	one[31] = 1
+
+	var tests = []struct {
+		name        string
+		rand        io.Reader
+		maxAttempts int
+	}{
+		{name: "constant zero", rand: &repeatingReader{b: []byte{0}}, maxAttempts: DefaultMaxAttempts},
+		{name: "repeating one", rand: &repeatingReader{b: one}, maxAttempts: 10},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := EncodeContext(t.Context(), p, test.rand, test.maxAttempts)
+			if !errors.Is(err, ErrEncodingFailed) {
+				t.Fatalf("EncodeContext() err = %v, want = %v", err, ErrEncodingFailed)
+			}
+
+			var efe *EncodingFailedError
+			if !errors.As(err, &efe) {
+				t.Fatalf("EncodeContext() err = %T, want = %T", err, efe)
+			}
+
+			if got, want := efe.Attempts, test.maxAttempts; got != want {
+				t.Errorf("Attempts = %d, want = %d", got, want)
+			}
+		})
+	}
+}
+
+func TestEncodeContextInvalidAttempts(t *testing.T) {
+	t.Parallel()
+
+	k, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, maxAttempts := range []int{0, -1} {
+		_, err := EncodeContext(t.Context(), k.PublicKey().Bytes(), rand.Reader, maxAttempts)
+		if !errors.Is(err, ErrInvalidAttempts) {
+			t.Errorf("EncodeContext(maxAttempts=%d) err = %v, want = %v", maxAttempts, err, ErrInvalidAttempts)
+		}
+
+		if err != nil && !strings.Contains(err.Error(), "maxAttempts") {
+			t.Errorf("EncodeContext(maxAttempts=%d) err = %q, want it to name maxAttempts", maxAttempts, err)
+		}
+	}
+}
+
+func TestEncodeConstantTimeInvalidAttempts(t *testing.T) {
+	t.Parallel()
+
+	k, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, attempts := range []int{0, -1} {
+		_, err := EncodeConstantTime(k.PublicKey().Bytes(), rand.Reader, attempts)
+		if !errors.Is(err, ErrInvalidAttempts) {
+			t.Errorf("EncodeConstantTime(attempts=%d) err = %v, want = %v", attempts, err, ErrInvalidAttempts)
+		}
+
+		if err != nil && !strings.Contains(err.Error(), "attempts") {
+			t.Errorf("EncodeConstantTime(attempts=%d) err = %q, want it to name attempts", attempts, err)
+		}
+	}
+}
+
+func TestEncodeContextCancelled(t *testing.T) {
+	t.Parallel()
+
+	k, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	if _, err := EncodeContext(ctx, k.PublicKey().Bytes(), rand.Reader, DefaultMaxAttempts); !errors.Is(err, context.Canceled) {
+		t.Errorf("EncodeContext() err = %v, want = %v", err, context.Canceled)
+	}
+}
+
+func TestEncodeShortRead(t *testing.T) {
+	t.Parallel()
+
+	k, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Encode(k.PublicKey().Bytes(), bytes.NewReader(make([]byte, 20))); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("Encode() err = %v, want = %v", err, io.ErrUnexpectedEOF)
+	}
+}
+
+func TestEncodeConstantTime(t *testing.T) {
+	t.Parallel()
+	for range 100 {
+		k, err := ecdh.P256().GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		encoded, err := EncodeConstantTime(k.PublicKey().Bytes(), rand.Reader, 128)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		q, err := Decode(encoded)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got, want := q, k.PublicKey().Bytes(); !bytes.Equal(got, want) {
+			t.Fatalf("Decode(%x) = %x, want = %x", encoded, got, want)
+		}
+	}
+}
+
+func TestEncodeConstantTimeFixedAttempts(t *testing.T) {
+	t.Parallel()
+
+	k, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := &countingReader{r: rand.Reader}
+	if _, err := EncodeConstantTime(k.PublicKey().Bytes(), r, 32); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := r.n, 32*33; got != want {
+		t.Errorf("read %d bytes, want = %d", got, want)
+	}
+}
+
+func TestEncodeConstantTimeFailureProbability(t *testing.T) {
+	t.Parallel()
+
+	k, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := k.PublicKey().Bytes()
+
+	// Each candidate succeeds with probability ~1/4, so a budget of n attempts should fail with
+	// probability ~(3/4)^n.
+	const trials = 1_000
+	for _, attempts := range []int{1, 2, 4} {
+		failures := 0
+		for range trials {
+			_, err := EncodeConstantTime(p, rand.Reader, attempts)
+			switch {
+			case errors.Is(err, ErrEncodingFailed):
+				failures++
+			case err != nil:
+				t.Fatal(err)
+			}
+		}
+
+		got, want := float64(failures)/trials, math.Pow(0.75, float64(attempts))
+		if math.Abs(got-want) > 0.05 {
+			t.Errorf("EncodeConstantTime(attempts=%d) failure rate = %f, want = %f", attempts, got, want)
+		}
+	}
+}
+
+func TestEncodeUniform(t *testing.T) {
+	t.Parallel()
+	for range 100 {
+		k, err := ecdh.P256().GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		encoded, err := EncodeUniform(k.PublicKey().Bytes(), rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		q, err := Decode(encoded)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got, want := q, k.PublicKey().Bytes(); !bytes.Equal(got, want) {
+			t.Fatalf("Decode(%x) = %x, want = %x", encoded, got, want)
+		}
+	}
+}
+
+func TestEncodeUniformLifted(t *testing.T) {
+	t.Parallel()
+
+	// A repeating reader which always produces u = 2^256 - 1, which is greater than p, with j = 3
+	// and no lift for v.
+	b := append(bytes.Repeat([]byte{0xff}, 32), 0x03)
+	for range 100 {
+		k, err := ecdh.P256().GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		encoded, err := EncodeUniform(k.PublicKey().Bytes(), &repeatingReader{b: b})
+		if errors.Is(err, ErrEncodingFailed) {
+			// The fixed candidate is not valid for this point; try another one.
+			continue
+		} else if err != nil {
+			t.Fatal(err)
+		}
+
+		if got, want := encoded[:32], b[:32]; !bytes.Equal(got, want) {
+			t.Fatalf("EncodeUniform() = %x, want u = %x", encoded, want)
+		}
+
+		q, err := Decode(encoded)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got, want := q, k.PublicKey().Bytes(); !bytes.Equal(got, want) {
+			t.Fatalf("Decode(%x) = %x, want = %x", encoded, got, want)
+		}
+		return
+	}
+	t.Fatal("no point could be encoded with u = 2^256 - 1")
+}
+
+func TestEncodeUniformLiftRate(t *testing.T) {
+	t.Parallel()
+
+	// Pick a v small enough to be lifted, and a point for which it is a preimage given a fixed u.
+	// Real encodings almost never have such a v, since it occurs with probability ~2^-32.
+	var vb [32]byte
+	if _, err := rand.Read(vb[8:]); err != nil {
+		t.Fatal(err)
+	}
+	vb[31] |= 2 // Avoid the exceptional values {-1, 0, 1}.
+
+	ub := [32]byte{31: 7}
+	var u, v, px, py, x, y fieldElement
+	u.SetBytes(&ub)
+	v.SetBytes(&vb)
+	f(&px, &py, &u)
+	f(&x, &y, &v)
+	p256.Add(&px, &py, &px, &py, &x, &y)
+	point := appendUncompressed(nil, &px, &py)
+
+	// Every candidate uses u, with a random root and lift bit.
+	const samples = 1_000
+	lifted, total := 0, 0
+	for range samples {
+		encoded, err := EncodeUniform(point, &fixedUReader{u: ub})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Skip encodings which used one of v's siblings among the preimages.
+		var got fieldElement
+		got.SetBytes((*[32]byte)(encoded[32:]))
+		if got.Equal(&v) != 1 {
+			continue
+		}
+
+		total++
+		if !bytes.Equal(encoded[32:], vb[:]) {
+			lifted++
+
+			// A lifted half is v + p, which reduces to v.
+			var want [32]byte
+			if v.Lift(&want, 1) != 1 || !bytes.Equal(encoded[32:], want[:]) {
+				t.Fatalf("EncodeUniform() v = %x, want = %x or %x", encoded[32:], vb, want)
+			}
+		}
+
+		q, err := Decode(encoded)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(q, point) {
+			t.Fatalf("Decode(%x) = %x, want = %x", encoded, q, point)
+		}
+	}
+
+	// v is lifted with probability 1/2, so this fails with probability ~2^-30 for 500 samples.
+	if total < samples/4 {
+		t.Fatalf("only %d of %d encodings used v", total, samples)
+	}
+
+	if rate := float64(lifted) / float64(total); rate < 0.4 || rate > 0.6 {
+		t.Errorf("lifted %d of %d encodings of v = %x, want about half", lifted, total, vb)
+	}
+}
+
+func TestEncodeUniformNeverLiftsLargeHalves(t *testing.T) {
+	t.Parallel()
+
+	// A random v is at least 2^256 - p with overwhelming probability, so it has no lift which fits
+	// in 256 bits.
+	var vb [32]byte
+	if _, err := rand.Read(vb[:]); err != nil {
+		t.Fatal(err)
+	}
+
+	ub := [32]byte{31: 7}
+	var u, v, px, py, x, y fieldElement
+	u.SetBytes(&ub)
+	v.SetBytes(&vb)
+	if v.Lift(new([32]byte), 1) == 1 {
+		t.Skipf("v = %x can be lifted", vb)
+	}
+	f(&px, &py, &u)
+	f(&x, &y, &v)
+	p256.Add(&px, &py, &px, &py, &x, &y)
+	point := appendUncompressed(nil, &px, &py)
+
+	// Every candidate uses u and asks for v to be lifted, so every candidate which would use v, or
+	// any of its siblings which cannot be lifted either, must be rejected rather than emitted as is.
+	encoded, err := EncodeUniform(point, &fixedUReader{u: ub, lift: true})
+	if errors.Is(err, ErrEncodingFailed) {
+		return
+	} else if err != nil {
+		t.Fatal(err)
+	}
+
+	var got fieldElement
+	got.SetBytes((*[32]byte)(encoded[32:]))
+	if bytes.Equal(encoded[32:], got.Bytes()) {
+		t.Errorf("EncodeUniform() = %x, want a lifted v", encoded)
+	}
+}
+
+func TestRerandomize(t *testing.T) {
+	t.Parallel()
+
+	k, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Tabulate the top two bits of each half of the input against those of the output, which should
+	// be independent.
+	const samples = 2_000
+	var counts [2][4][4]int
+	for range samples {
+		encoded, err := Encode(k.PublicKey().Bytes(), rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		rerandomized, err := Rerandomize(encoded, rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if bytes.Equal(encoded, rerandomized) {
+			t.Fatalf("Rerandomize(%x) = %x, want a different bitstring", encoded, rerandomized)
+		}
+
+		q, err := Decode(rerandomized)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got, want := q, k.PublicKey().Bytes(); !bytes.Equal(got, want) {
+			t.Fatalf("Decode(%x) = %x, want = %x", rerandomized, got, want)
+		}
+
+		counts[0][encoded[0]>>6][rerandomized[0]>>6]++
+		counts[1][encoded[32]>>6][rerandomized[32]>>6]++
+	}
+
+	// The 99.9th percentile of the chi-squared distribution with 9 degrees of freedom is 27.88.
+	for i, c := range counts {
+		var rows, cols [4]int
+		for a := range 4 {
+			for b := range 4 {
+				rows[a] += c[a][b]
+				cols[b] += c[a][b]
+			}
+		}
+
+		var chi2 float64
+		for a := range 4 {
+			for b := range 4 {
+				e := float64(rows[a]) * float64(cols[b]) / samples
+				d := float64(c[a][b]) - e
+				chi2 += d * d / e
+			}
+		}
+
+		if chi2 > 27.88 {
+			t.Errorf("half %d: chi-squared = %f, counts = %v", i, chi2, c)
+		}
+	}
+}
+
+func TestRerandomizeInvalid(t *testing.T) {
+	t.Parallel()
+
+	if _, err := Rerandomize(make([]byte, 63), rand.Reader); !errors.Is(err, ErrInvalidEncoding) {
+		t.Errorf("Rerandomize(short) = %v, want = %v", err, ErrInvalidEncoding)
+	}
+
+	if _, err := Rerandomize(make([]byte, 64), rand.Reader); !errors.Is(err, ErrIdentity) {
+		t.Errorf("Rerandomize(identity) = %v, want = %v", err, ErrIdentity)
+	}
+}
+
+// fixedUReader produces candidates with a fixed u and a random root and lift bit, or a random root
+// and a set lift bit if lift is true.
+type fixedUReader struct {
+	u    [32]byte
+	lift bool
+	i    int
+}
+
+func (r *fixedUReader) Read(p []byte) (int, error) {
+	for i := range p {
+		if r.i%33 < 32 {
+			p[i] = r.u[r.i%33]
+		} else if _, err := rand.Read(p[i : i+1]); err != nil {
+			return i, err
+		} else if r.lift {
+			p[i] |= 4
+		}
+		r.i++
+	}
+	return len(p), nil
+}
+
+type repeatingReader struct {
+	b []byte
+	i int
+}
+
+func (r *repeatingReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = r.b[r.i%len(r.b)]
+		r.i++
+	}
+	return len(p), nil
+}
+
+type countingReader struct {
+	r io.Reader
+	n int
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.n += n
+	return n, err
+}
+
 func TestX0(t *testing.T) {
 	t.Parallel()
 
@@ -175,7 +852,7 @@ func TestX0(t *testing.T) {
 	for _, test := range tests {
 		t.Run(fmt.Sprintf("x_0(%s)", test.x), func(t *testing.T) {
 			t.Parallel()
-			if got, want := x0(test.x), test.want; got.Cmp(want) != 0 {
+			if got, want := x0(new(fieldElement), test.x), test.want; got.Equal(want) != 1 {
 				t.Errorf("x_0(%s) = %s, want = %s", test.x, got, want)
 			}
 		})
@@ -231,7 +908,7 @@ func TestX1(t *testing.T) {
 	for _, test := range tests {
 		t.Run(fmt.Sprintf("x_1(%s)", test.x), func(t *testing.T) {
 			t.Parallel()
-			if got, want := x1(test.x), test.want; got.Cmp(want) != 0 {
+			if got, want := x1(new(fieldElement), test.x), test.want; got.Equal(want) != 1 {
 				t.Errorf("x_1(%s) = %s, want = %s", test.x, got, want)
 			}
 		})
@@ -297,9 +974,10 @@ func TestF(t *testing.T) {
 	for _, test := range tests {
 		t.Run(fmt.Sprintf("f(%s)", test.u), func(t *testing.T) {
 			t.Parallel()
-			gotX, gotY := f(test.u)
-			if gotX.Cmp(test.x) != 0 || gotY.Cmp(test.y) != 0 {
-				t.Errorf("f(%s) = (%s, %s), want = (%s, %s)", test.u, gotX, gotY, test.x, test.y)
+			var gotX, gotY fieldElement
+			f(&gotX, &gotY, test.u)
+			if gotX.Equal(test.x) != 1 || gotY.Equal(test.y) != 1 {
+				t.Errorf("f(%s) = (%s, %s), want = (%s, %s)", test.u, &gotX, &gotY, test.x, test.y)
 			}
 		})
 	}
@@ -309,6 +987,7 @@ func TestDecode(t *testing.T) {
 	t.Parallel()
 	var tests = []struct {
 		x, want string
+		err     error
 	}{
 		{
 			x:    "6dab76bdcab43eb44959c0c57dd4f771625177a2f41bb407797a2d6a0ec64db011d88d5ec0faff56e1acba5c00e9fe317de9a3ac95c1421dc01bae9248a0e910",
@@ -350,6 +1029,26 @@ func TestDecode(t *testing.T) {
 			x:    "465dbe10735a2a019d7d48efa6c96ff262a06478f3024dc3d38552956d74d8213283fd22bcd3b2432f2fc2f7a2313e1e5b13c44ff018c45089c47cb2f2413fda",
 			want: "0480f21f22b85b8acf54e878227540fc34e74f5b67da801d123890b5a02a386299d2158a81318befb98129cb9a582aa1795f2d5ca43025db08c0f6006e16006b06",
 		},
+		{
+			x:   "e008e441fed0b0c24598be35c8d12831d85e331d5569102ee36eff3f68e74bc6e6e72e8bb89985e00a044013c7f4586f55361543786ca8d099b5c3a5925ef99c",
+			err: ErrIdentity,
+		},
+		{
+			x:   "82c014e6bc2deb6bf793012387e3458e0b50039beefb88b95236e6b1ddbcf629bdbe0e7b09cae8f665eb7fed0613eba774a58f111ec41f0cfa40ff7dd26aa60f",
+			err: ErrIdentity,
+		},
+		{
+			x:   "a429cfe4d6f8e3f3fd1feb2f4e56cbb0aa1f1e35575c81032bee374e62934420e8f5f61401e5e1d7c15d217604c32f72ee03504af495d6011de0ae3066cc9d37",
+			err: ErrIdentity,
+		},
+		{
+			x:   "00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000001",
+			err: ErrIdentity,
+		},
+		{
+			x:   "6dab76bdcab43eb44959c0c57dd4f771625177a2f41bb407797a2d6a0ec64db011d88d5ec0faff56e1acba5c00e9fe317de9a3ac95c1421dc01bae9248a0e9",
+			err: ErrInvalidEncoding,
+		},
 	}
 	for _, test := range tests {
 		t.Run(fmt.Sprintf("Decode(%s)", test.x), func(t *testing.T) {
@@ -360,13 +1059,157 @@ func TestDecode(t *testing.T) {
 			}
 
 			p, err := Decode(b)
-			if err != nil && test.want != "" {
-				t.Fatal(err)
+			if !errors.Is(err, test.err) {
+				t.Fatalf("Decode(%s) err = %v, want = %v", test.x, err, test.err)
 			}
 
-			if got := hex.EncodeToString(p); p != nil && got != test.want {
+			if got := hex.EncodeToString(p); got != test.want {
 				t.Errorf("Decode(%s) = %s, want = %s", test.x, got, test.want)
 			}
 		})
 	}
 }
+
+func TestDecodeFormats(t *testing.T) {
+	t.Parallel()
+	for range 100 {
+		k, err := ecdh.P256().GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		p := k.PublicKey().Bytes()
+
+		encoded, err := Encode(p, rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		c, err := DecodeCompressed(encoded)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got, want := c, append([]byte{2 | p[64]&1}, p[1:33]...); !bytes.Equal(got, want) {
+			t.Fatalf("DecodeCompressed(%x) = %x, want = %x", encoded, got, want)
+		}
+
+		x, y, err := DecodeXY(encoded)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(x, p[1:33]) || !bytes.Equal(y, p[33:]) {
+			t.Fatalf("DecodeXY(%x) = (%x, %x), want = (%x, %x)", encoded, x, y, p[1:33], p[33:])
+		}
+	}
+}
+
+func TestDecodeFormatsIdentity(t *testing.T) {
+	t.Parallel()
+
+	b := make([]byte, 64)
+	if _, err := DecodeCompressed(b); !errors.Is(err, ErrIdentity) {
+		t.Errorf("DecodeCompressed(%x) err = %v, want = %v", b, err, ErrIdentity)
+	}
+
+	if _, _, err := DecodeXY(b); !errors.Is(err, ErrIdentity) {
+		t.Errorf("DecodeXY(%x) err = %v, want = %v", b, err, ErrIdentity)
+	}
+}
+
+func TestDecodeStrict(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		name, x, want string
+		err           error
+	}{
+		{
+			name: "valid",
+			x:    "6dab76bdcab43eb44959c0c57dd4f771625177a2f41bb407797a2d6a0ec64db011d88d5ec0faff56e1acba5c00e9fe317de9a3ac95c1421dc01bae9248a0e910",
+			want: "04083c0f5503e23eaabca86f32cbf603eb1fbb037701b9bf94d053ce57a84e367cf2e282d17fd64220c64c9fe12e347971b86760d30821f75cdae9bfb0294ab5df",
+		},
+		{
+			name: "short",
+			x:    "6dab76bdcab43eb44959c0c57dd4f771625177a2f41bb407797a2d6a0ec64db011d88d5ec0faff56e1acba5c00e9fe317de9a3ac95c1421dc01bae9248a0e9",
+			err:  ErrInvalidEncoding,
+		},
+		{
+			name: "u = p",
+			x:    "ffffffff00000001000000000000000000000000ffffffffffffffffffffffff11d88d5ec0faff56e1acba5c00e9fe317de9a3ac95c1421dc01bae9248a0e910",
+			err:  ErrInvalidEncoding,
+		},
+		{
+			name: "v = 2^256 - 1",
+			x:    "6dab76bdcab43eb44959c0c57dd4f771625177a2f41bb407797a2d6a0ec64db0ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+			err:  ErrInvalidEncoding,
+		},
+		{
+			name: "u = 0",
+			x:    "000000000000000000000000000000000000000000000000000000000000000011d88d5ec0faff56e1acba5c00e9fe317de9a3ac95c1421dc01bae9248a0e910",
+			err:  ErrInvalidEncoding,
+		},
+		{
+			name: "u = 1",
+			x:    "000000000000000000000000000000000000000000000000000000000000000111d88d5ec0faff56e1acba5c00e9fe317de9a3ac95c1421dc01bae9248a0e910",
+			err:  ErrInvalidEncoding,
+		},
+		{
+			name: "u = -1",
+			x:    "ffffffff00000001000000000000000000000000fffffffffffffffffffffffe11d88d5ec0faff56e1acba5c00e9fe317de9a3ac95c1421dc01bae9248a0e910",
+			err:  ErrInvalidEncoding,
+		},
+		{
+			name: "v = 0",
+			x:    "6dab76bdcab43eb44959c0c57dd4f771625177a2f41bb407797a2d6a0ec64db00000000000000000000000000000000000000000000000000000000000000000",
+			err:  ErrInvalidEncoding,
+		},
+		{
+			name: "identity",
+			x:    "e008e441fed0b0c24598be35c8d12831d85e331d5569102ee36eff3f68e74bc6e6e72e8bb89985e00a044013c7f4586f55361543786ca8d099b5c3a5925ef99c",
+			err:  ErrIdentity,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			b, err := hex.DecodeString(test.x)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			p, err := DecodeStrict(b)
+			if !errors.Is(err, test.err) {
+				t.Fatalf("DecodeStrict(%s) err = %v, want = %v", test.x, err, test.err)
+			}
+
+			if got := hex.EncodeToString(p); got != test.want {
+				t.Errorf("DecodeStrict(%s) = %s, want = %s", test.x, got, test.want)
+			}
+		})
+	}
+}
+
+func TestDecodeStrictRoundTrip(t *testing.T) {
+	t.Parallel()
+	for range 100 {
+		k, err := ecdh.P256().GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		encoded, err := Encode(k.PublicKey().Bytes(), rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		q, err := DecodeStrict(encoded)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got, want := q, k.PublicKey().Bytes(); !bytes.Equal(got, want) {
+			t.Fatalf("DecodeStrict(%x) = %x, want = %x", encoded, got, want)
+		}
+	}
+}
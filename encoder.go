@@ -0,0 +1,255 @@
+package elligator
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"fmt"
+	"io"
+	"slices"
+)
+
+// PointFormat is a set of SEC point encoding formats.
+type PointFormat uint8
+
+const (
+	// FormatUncompressed is the 65-byte uncompressed format, prefixed with 0x04.
+	FormatUncompressed PointFormat = 1 << iota
+	// FormatCompressed is the 33-byte compressed format, prefixed with 0x02 or 0x03.
+	FormatCompressed
+	// FormatHybrid is the 65-byte ANSI X9.62 hybrid format, prefixed with 0x06 or 0x07.
+	FormatHybrid
+
+	// AllFormats is the set of all supported formats.
+	AllFormats = FormatUncompressed | FormatCompressed | FormatHybrid
+)
+
+// An Encoder maps points to random 64-byte bitstrings with a fixed configuration.
+//
+// The zero value is ready to use, and behaves like an Encoder returned by NewEncoder with no
+// options. An Encoder is safe for concurrent use by multiple goroutines as long as its random
+// source is. The default source, crypto/rand.Reader, is.
+type Encoder struct {
+	rand         io.Reader
+	attempts     int
+	attemptsSet  bool
+	constantTime bool
+	uniform      bool
+	allPreimages bool
+	sswu         bool
+	formats      PointFormat
+}
+
+// EncoderOption configures an Encoder.
+type EncoderOption func(e *Encoder)
+
+// NewEncoder returns an Encoder with the given options. By default, an Encoder uses
+// crypto/rand.Reader, tries at most DefaultMaxAttempts candidates in variable time, produces
+// canonical encodings, and accepts points in all formats.
+func NewEncoder(opts ...EncoderOption) *Encoder {
+	e := &Encoder{
+		rand:     rand.Reader,
+		attempts: DefaultMaxAttempts,
+		formats:  AllFormats,
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
+}
+
+// WithRand sets the Encoder's random source. A nil source means crypto/rand.Reader.
+func WithRand(rand io.Reader) EncoderOption {
+	return func(e *Encoder) {
+		e.rand = rand
+	}
+}
+
+// WithMaxAttempts sets the maximum number of candidates the Encoder tries before returning an
+// *EncodingFailedError. As with EncodeContext, a number less than 1 makes encoding fail with
+// ErrInvalidAttempts.
+func WithMaxAttempts(maxAttempts int) EncoderOption {
+	return func(e *Encoder) {
+		e.attempts = maxAttempts
+		e.attemptsSet = true
+		e.constantTime = false
+	}
+}
+
+// WithConstantTime makes the Encoder always try exactly the given number of candidates, as with
+// EncodeConstantTime. It overrides WithMaxAttempts, and vice versa. As with EncodeConstantTime, a
+// number less than 1 makes encoding fail with ErrInvalidAttempts.
+func WithConstantTime(attempts int) EncoderOption {
+	return func(e *Encoder) {
+		e.attempts = attempts
+		e.attemptsSet = true
+		e.constantTime = true
+	}
+}
+
+// WithUniform makes the Encoder produce encodings which are uniform over all 2^512 bitstrings, as
+// with EncodeUniform. Those encodings are rejected by DecodeStrict. Uniform encoding halves the
+// success probability of each candidate, so constant-time budgets should be doubled.
+func WithUniform() EncoderOption {
+	return func(e *Encoder) {
+		e.uniform = true
+	}
+}
+
+// WithAllPreimages makes the Encoder use the sampling algorithm from the Elligator Squared paper:
+// for each random u, it computes every preimage of p - f(u) at once, accepts u with probability
+// t/4, where t is the number of preimages, and picks one of them uniformly. The encodings have the
+// same distribution, and the same number of candidates are tried on average, but a u without
+// preimages is rejected before the more expensive steps, so each candidate costs about 15% less.
+//
+// It is not constant-time: the time taken to encode a point depends on the point and leaks how its
+// candidates were rejected. Use it only for points which are not secret. It is ignored if
+// WithConstantTime is set.
+func WithAllPreimages() EncoderOption {
+	return func(e *Encoder) {
+		e.allPreimages = true
+	}
+}
+
+// WithFormats restricts the point formats the Encoder accepts. Points in any other format are
+// rejected with ErrInvalidPoint. An empty set means AllFormats.
+func WithFormats(formats PointFormat) EncoderOption {
+	return func(e *Encoder) {
+		e.formats = formats
+	}
+}
+
+// Encode maps the given SEC-encoded point to a random 64-byte bitstring.
+func (e *Encoder) Encode(p []byte) ([]byte, error) {
+	return e.appendEncode(context.Background(), nil, p)
+}
+
+// EncodeContext is like Encode, but returns ctx.Err() if ctx is done before a candidate succeeds.
+func (e *Encoder) EncodeContext(ctx context.Context, p []byte) ([]byte, error) {
+	return e.appendEncode(ctx, nil, p)
+}
+
+// AppendEncode is like Encode, but appends the 64-byte bitstring to dst and returns the extended
+// buffer. It does not allocate if dst has at least 64 bytes of spare capacity.
+func (e *Encoder) AppendEncode(dst, p []byte) ([]byte, error) {
+	return e.appendEncode(context.Background(), dst, p)
+}
+
+// appendEncode maps the given SEC-encoded point to a random 64-byte bitstring and appends it to dst.
+func (e *Encoder) appendEncode(ctx context.Context, dst, p []byte) ([]byte, error) {
+	var px, py fieldElement
+	if err := p256SetBytes(&px, &py, p, e.pointFormats()); err != nil {
+		return dst, err
+	}
+	return e.appendEncodePoint(ctx, dst, &px, &py)
+}
+
+// appendEncodePoint maps the affine point (px, py) to a random 64-byte bitstring and appends it to
+// dst.
+func (e *Encoder) appendEncodePoint(ctx context.Context, dst []byte, px, py *fieldElement) ([]byte, error) {
+	attempts, err := e.maxAttempts()
+	if err != nil {
+		return dst, err
+	}
+	rand := e.random()
+
+	// Read the random bytes into the space the output will occupy, since passing a stack buffer to
+	// rand.Read would force it to escape to the heap. The chosen candidate is kept on the stack and
+	// only copied over them once found, so nothing is written past the returned length.
+	n := len(dst)
+	ret := slices.Grow(dst, 64)[:n+64]
+	buf := ret[n : n+33]
+	fail := func(err error) ([]byte, error) {
+		clear(buf)
+		return dst, err
+	}
+
+	var out [64]byte
+	defer clear(out[:])
+
+	var u, v fieldElement
+	var ub, vb [32]byte
+	found := 0
+	for range attempts {
+		if err := ctx.Err(); err != nil {
+			return fail(err)
+		}
+
+		// Generate a random field element, a random biquadratic root from [0,4), and a random lift
+		// bit.
+		if _, err := io.ReadFull(rand, buf); err != nil {
+			return fail(err)
+		}
+		u.SetBytes((*[32]byte)(buf[:32]))
+		var ok int
+		switch {
+		case e.sswu:
+			ok = sswuCandidate(&v, px, py, &u, buf[32]&3)
+		case e.allPreimages && !e.constantTime:
+			ok = sampleCandidate(&v, px, py, &u, buf[32]&3)
+		default:
+			ok = candidate(&v, px, py, &u, buf[32]&3)
+		}
+
+		if e.uniform {
+			// u was sampled by reducing a uniform 256-bit string, so that string is already a
+			// uniformly lifted representative of u. v is lifted by adding p with probability 1/2,
+			// rejecting the candidate if the result does not fit in 256 bits, which weights each v
+			// by its number of lifts.
+			copy(ub[:], buf[:32])
+			ok &= v.Lift(&vb, int(buf[32]>>2)&1)
+		} else {
+			u.FillBytes(&ub)
+			v.FillBytes(&vb)
+		}
+
+		// Keep the candidate only if it is valid and no earlier candidate was.
+		ok &^= found
+		subtle.ConstantTimeCopy(ok, out[:32], ub[:])
+		subtle.ConstantTimeCopy(ok, out[32:], vb[:])
+		found |= ok
+
+		// Check the public flag first, so that in constant-time mode the secret found is never
+		// branched on.
+		if !e.constantTime && found == 1 {
+			break
+		}
+	}
+
+	if found != 1 {
+		return fail(&EncodingFailedError{Attempts: attempts})
+	}
+
+	copy(ret[n:], out[:])
+	return ret, nil
+}
+
+// random returns the Encoder's random source, or crypto/rand.Reader if it has none.
+func (e *Encoder) random() io.Reader {
+	if e.rand == nil {
+		return rand.Reader
+	}
+	return e.rand
+}
+
+// maxAttempts returns the number of candidates the Encoder tries, or DefaultMaxAttempts if it is
+// the zero value and no option set it. It returns an error wrapping ErrInvalidAttempts if an option
+// set it to less than 1.
+func (e *Encoder) maxAttempts() (int, error) {
+	switch {
+	case e.attempts == 0 && !e.attemptsSet:
+		return DefaultMaxAttempts, nil
+	case e.attempts < 1:
+		return 0, fmt.Errorf("%w: attempts must be at least 1, got %d", ErrInvalidAttempts, e.attempts)
+	default:
+		return e.attempts, nil
+	}
+}
+
+// pointFormats returns the formats the Encoder accepts, or AllFormats if it has none.
+func (e *Encoder) pointFormats() PointFormat {
+	if e.formats == 0 {
+		return AllFormats
+	}
+	return e.formats
+}
@@ -0,0 +1,258 @@
+package elligator
+
+import (
+	"bytes"
+	"crypto/ecdh"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func ExampleEncoder() {
+	// Create an Encoder which runs in constant time and produces uniform encodings.
+	e := NewEncoder(WithConstantTime(256), WithUniform())
+
+	// Generate a P-256 ECDH key pair.
+	k, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+
+	// Encode the public key.
+	encoded, err := e.Encode(k.PublicKey().Bytes())
+	if err != nil {
+		panic(err)
+	}
+
+	// Decode the public key.
+	qP, err := Decode(encoded)
+	if err != nil {
+		panic(err)
+	}
+
+	// Compare the two.
+	fmt.Println(bytes.Equal(k.PublicKey().Bytes(), qP))
+	// Output: true
+}
+
+func TestEncoderOptions(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		name string
+		opts []EncoderOption
+	}{
+		{name: "default"},
+		{name: "max attempts", opts: []EncoderOption{WithMaxAttempts(500)}},
+		{name: "constant time", opts: []EncoderOption{WithConstantTime(128)}},
+		{name: "uniform", opts: []EncoderOption{WithUniform()}},
+		{name: "constant time uniform", opts: []EncoderOption{WithConstantTime(256), WithUniform()}},
+		{name: "rand", opts: []EncoderOption{WithRand(rand.Reader)}},
+		{name: "all preimages", opts: []EncoderOption{WithAllPreimages()}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			e := NewEncoder(test.opts...)
+			for range 10 {
+				k, err := ecdh.P256().GenerateKey(rand.Reader)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				encoded, err := e.Encode(k.PublicKey().Bytes())
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				q, err := Decode(encoded)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if got, want := q, k.PublicKey().Bytes(); !bytes.Equal(got, want) {
+					t.Fatalf("Decode(%x) = %x, want = %x", encoded, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestEncoderAttempts(t *testing.T) {
+	t.Parallel()
+
+	k, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := k.PublicKey().Bytes()
+
+	t.Run("max attempts", func(t *testing.T) {
+		t.Parallel()
+
+		e := NewEncoder(WithRand(&repeatingReader{b: []byte{0}}), WithMaxAttempts(7))
+		var efe *EncodingFailedError
+		if _, err := e.Encode(p); !errors.As(err, &efe) || efe.Attempts != 7 {
+			t.Errorf("Encode() err = %v, want = %v", err, &EncodingFailedError{Attempts: 7})
+		}
+	})
+
+	t.Run("constant time", func(t *testing.T) {
+		t.Parallel()
+
+		r := &countingReader{r: rand.Reader}
+		e := NewEncoder(WithRand(r), WithConstantTime(16))
+		if _, err := e.Encode(p); err != nil && !errors.Is(err, ErrEncodingFailed) {
+			t.Fatal(err)
+		}
+
+		if got, want := r.n, 16*33; got != want {
+			t.Errorf("read %d bytes, want = %d", got, want)
+		}
+	})
+}
+
+func TestEncoderZeroValue(t *testing.T) {
+	t.Parallel()
+
+	var e Encoder
+	k, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p := k.PublicKey().Bytes()
+	compressed := append([]byte{2 | p[64]&1}, p[1:33]...)
+	for _, p := range [][]byte{p, compressed} {
+		encoded, err := e.Encode(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		q, err := Decode(encoded)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got, want := q, k.PublicKey().Bytes(); !bytes.Equal(got, want) {
+			t.Fatalf("Decode(%x) = %x, want = %x", encoded, got, want)
+		}
+	}
+
+	// A constant-time Encoder with no attempts set tries DefaultMaxAttempts candidates.
+	r := &countingReader{r: rand.Reader}
+	e = Encoder{rand: r, constantTime: true}
+	if _, err := e.Encode(p); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := r.n, DefaultMaxAttempts*33; got != want {
+		t.Errorf("read %d bytes, want = %d", got, want)
+	}
+}
+
+func TestEncoderInvalidAttempts(t *testing.T) {
+	t.Parallel()
+
+	k, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Zero is invalid here just as it is for EncodeContext and EncodeConstantTime.
+	for _, n := range []int{0, -1} {
+		for _, opt := range []EncoderOption{WithMaxAttempts(n), WithConstantTime(n)} {
+			if _, err := NewEncoder(opt).Encode(k.PublicKey().Bytes()); !errors.Is(err, ErrInvalidAttempts) {
+				t.Errorf("Encode(attempts=%d) err = %v, want = %v", n, err, ErrInvalidAttempts)
+			}
+
+			if _, err := NewEncoder(opt).EncodeContext(t.Context(), k.PublicKey().Bytes()); !errors.Is(err, ErrInvalidAttempts) {
+				t.Errorf("EncodeContext(attempts=%d) err = %v, want = %v", n, err, ErrInvalidAttempts)
+			}
+		}
+	}
+}
+
+func TestEncoderFormats(t *testing.T) {
+	t.Parallel()
+
+	uncompressed, err := hex.DecodeString("046b17d1f2e12c4247f8bce6e563a440f277037d812deb33a0f4a13945d898c2964fe342e2fe1a7f9b8ee7eb4a7c0f9e162bce33576b315ececbb6406837bf51f5")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	compressed, err := hex.DecodeString("036b17d1f2e12c4247f8bce6e563a440f277037d812deb33a0f4a13945d898c296")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hybrid := bytes.Clone(uncompressed)
+	hybrid[0] = 7
+
+	var tests = []struct {
+		name    string
+		formats PointFormat
+		p       []byte
+		err     error
+	}{
+		{name: "uncompressed allowed", formats: FormatUncompressed, p: uncompressed},
+		{name: "uncompressed rejected", formats: FormatCompressed | FormatHybrid, p: uncompressed, err: ErrInvalidPoint},
+		{name: "compressed allowed", formats: FormatCompressed, p: compressed},
+		{name: "compressed rejected", formats: FormatUncompressed | FormatHybrid, p: compressed, err: ErrInvalidPoint},
+		{name: "hybrid allowed", formats: FormatHybrid, p: hybrid},
+		{name: "hybrid rejected", formats: FormatUncompressed | FormatCompressed, p: hybrid, err: ErrInvalidPoint},
+		{name: "identity", formats: AllFormats, p: []byte{0}, err: ErrIdentity},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			e := NewEncoder(WithFormats(test.formats))
+			if _, err := e.Encode(test.p); !errors.Is(err, test.err) {
+				t.Errorf("Encode(%x) err = %v, want = %v", test.p, err, test.err)
+			}
+		})
+	}
+}
+
+func TestEncoderConcurrent(t *testing.T) {
+	t.Parallel()
+
+	e := NewEncoder(WithUniform())
+
+	var wg sync.WaitGroup
+	wg.Add(8)
+	for range 8 {
+		go func() {
+			defer wg.Done()
+			for range 10 {
+				k, err := ecdh.P256().GenerateKey(rand.Reader)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+
+				encoded, err := e.AppendEncode(make([]byte, 0, 64), k.PublicKey().Bytes())
+				if err != nil {
+					t.Error(err)
+					return
+				}
+
+				q, err := Decode(encoded)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+
+				if got, want := q, k.PublicKey().Bytes(); !bytes.Equal(got, want) {
+					t.Errorf("Decode(%x) = %x, want = %x", encoded, got, want)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,70 @@
+package elligator
+
+import (
+	"errors"
+
+	"github.com/codahale/elligator-squared-p256/internal/p256"
+	"github.com/codahale/elligator-squared-p256/internal/xmd"
+)
+
+// ErrInvalidDST is returned when a hash-to-curve domain separation tag is empty.
+var ErrInvalidDST = errors.New("elligator: invalid domain separation tag")
+
+// HashToCurve hashes msg to a P-256 point using the P256_XMD:SHA-256_SSWU_RO_ suite from RFC 9380,
+// Section 8.2, with the given domain separation tag, and returns its uncompressed SEC encoding.
+// The output is indistinguishable from a random oracle's. It returns ErrInvalidDST if dst is empty.
+//
+// HashToCurve is hash_to_field(msg, 2) followed by map_to_curve_simple_swu for each element, the
+// same map as EncodeSSWU, so DecodeSSWU(u[0] || u[1]) is the same point.
+func HashToCurve(msg, dst []byte) ([]byte, error) {
+	var u [2]fieldElement
+	if err := hashToField(u[:], msg, dst); err != nil {
+		return nil, err
+	}
+
+	var x, y, x2, y2 fieldElement
+	sswu(&x, &y, &u[0])
+	sswu(&x2, &y2, &u[1])
+	p256.Add(&x, &y, &x, &y, &x2, &y2)
+
+	// P-256 has cofactor 1, so clear_cofactor is the identity map. The sum of the two points is
+	// only the point at infinity with negligible probability.
+	if x.IsZero()&y.IsZero() == 1 {
+		return nil, ErrIdentity
+	}
+	return appendUncompressed(nil, &x, &y), nil
+}
+
+// EncodeToCurve hashes msg to a P-256 point using the P256_XMD:SHA-256_SSWU_NU_ suite from RFC
+// 9380, Section 8.2, with the given domain separation tag, and returns its uncompressed SEC
+// encoding. It is cheaper than HashToCurve, but its output is not uniformly distributed over the
+// curve. It returns ErrInvalidDST if dst is empty.
+func EncodeToCurve(msg, dst []byte) ([]byte, error) {
+	var u [1]fieldElement
+	if err := hashToField(u[:], msg, dst); err != nil {
+		return nil, err
+	}
+
+	var x, y fieldElement
+	sswu(&x, &y, &u[0])
+	return appendUncompressed(nil, &x, &y), nil
+}
+
+// hashToField sets each element of u to a field element derived from msg and dst, as in
+// hash_to_field from RFC 9380, Section 5.2, with L = 48. It returns ErrInvalidDST if dst is empty.
+func hashToField(u []fieldElement, msg, dst []byte) error {
+	const l = 48
+	if len(dst) == 0 {
+		return ErrInvalidDST
+	}
+
+	b, err := xmd.Expand(msg, dst, len(u)*l)
+	if err != nil {
+		return err
+	}
+
+	for i := range u {
+		u[i].SetWideBytes(b[i*l : (i+1)*l])
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package elligator
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestHashToCurve(t *testing.T) {
+	t.Parallel()
+
+	// The P256_XMD:SHA-256_SSWU_RO_ vectors from RFC 9380, Appendix J.1.1.
+	const dst = "QUUX-V01-CS02-with-P256_XMD:SHA-256_SSWU_RO_"
+	var tests = []struct {
+		msg, p string
+	}{
+		{
+			msg: "",
+			p:   "042c15230b26dbc6fc9a37051158c95b79656e17a1a920b11394ca91c44247d3e48a7a74985cc5c776cdfe4b1f19884970453912e9d31528c060be9ab5c43e8415",
+		},
+		{
+			msg: "abc",
+			p:   "040bb8b87485551aa43ed54f009230450b492fead5f1cc91658775dac4a3388a0f5c41b3d0731a27a7b14bc0bf0ccded2d8751f83493404c84a88e71ffd424212e",
+		},
+		{
+			msg: "abcdef0123456789",
+			p:   "0465038ac8f2b1def042a5df0b33b1f4eca6bff7cb0f9c6c1526811864e544ed80cad44d40a656e7aff4002a8de287abc8ae0482b5ae825822bb870d6df9b56ca3",
+		},
+		{
+			msg: "q128_" + strings.Repeat("q", 128),
+			p:   "044be61ee205094282ba8a2042bcb48d88dfbb609301c49aa8b078533dc65a0b5d98f8df449a072c4721d241a3b1236d3caccba603f916ca680f4539d2bfb3c29e",
+		},
+		{
+			msg: "a512_" + strings.Repeat("a", 512),
+			p:   "04457ae2981f70ca85d8e24c308b14db22f3e3862c5ea0f652ca38b5e49cd64bc5ecb9f0eadc9aeed232dabc53235368c1394c78de05dd96893eefa62b0f4757dc",
+		},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("HashToCurve(%.20q)", test.msg), func(t *testing.T) {
+			t.Parallel()
+
+			p, err := HashToCurve([]byte(test.msg), []byte(dst))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got, want := hex.EncodeToString(p), test.p; got != want {
+				t.Errorf("HashToCurve(%.20q) = %s, want = %s", test.msg, got, want)
+			}
+		})
+	}
+}
+
+func TestEncodeToCurve(t *testing.T) {
+	t.Parallel()
+
+	// The P256_XMD:SHA-256_SSWU_NU_ vectors from RFC 9380, Appendix J.1.2.
+	const dst = "QUUX-V01-CS02-with-P256_XMD:SHA-256_SSWU_NU_"
+	var tests = []struct {
+		msg, p string
+	}{
+		{
+			msg: "",
+			p:   "04f871caad25ea3b59c16cf87c1894902f7e7b2c822c3d3f73596c5ace8ddd14d187b9ae23335bee057b99bac1e68588b18b5691af476234b8971bc4f011ddc99b",
+		},
+		{
+			msg: "abc",
+			p:   "04fc3f5d734e8dce41ddac49f47dd2b8a57257522a865c124ed02b92b5237befa4fe4d197ecf5a62645b9690599e1d80e82c500b22ac705a0b421fac7b47157866",
+		},
+		{
+			msg: "abcdef0123456789",
+			p:   "04f164c6674a02207e414c257ce759d35eddc7f55be6d7f415e2cc177e5d8faa843aa274881d30db70485368c0467e97da0e73c18c1d00f34775d012b6fcee7f97",
+		},
+		{
+			msg: "q128_" + strings.Repeat("q", 128),
+			p:   "04324532006312be4f162614076460315f7a54a6f85544da773dc659aca03118538d8197374bcd52de2acfefc8a54fe2c8d8bebd2a39f16be9b710e4b1af6ef883",
+		},
+		{
+			msg: "a512_" + strings.Repeat("a", 512),
+			p:   "045c4bad52f81f39c8e8de1260e9a06d72b8b00a0829a8ea004a610b0691bea5d9c801e7c0782af1f74f24fc385a8555da0582032a3ce038de637ccdcb16f7ef7b",
+		},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("EncodeToCurve(%.20q)", test.msg), func(t *testing.T) {
+			t.Parallel()
+
+			p, err := EncodeToCurve([]byte(test.msg), []byte(dst))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got, want := hex.EncodeToString(p), test.p; got != want {
+				t.Errorf("EncodeToCurve(%.20q) = %s, want = %s", test.msg, got, want)
+			}
+		})
+	}
+}
+
+func TestHashToCurveInvalidDST(t *testing.T) {
+	t.Parallel()
+
+	if _, err := HashToCurve([]byte("msg"), nil); !errors.Is(err, ErrInvalidDST) {
+		t.Errorf("HashToCurve(dst = nil) = %v, want = %v", err, ErrInvalidDST)
+	}
+
+	if _, err := EncodeToCurve([]byte("msg"), nil); !errors.Is(err, ErrInvalidDST) {
+		t.Errorf("EncodeToCurve(dst = nil) = %v, want = %v", err, ErrInvalidDST)
+	}
+}
@@ -0,0 +1,415 @@
+// Package p256 implements the P-256 base field, scalar field, and group operations shared by the
+// elligator and oprf packages.
+package p256
+
+import (
+	"encoding/hex"
+	"math/bits"
+)
+
+// Element is an integer modulo 2^256 - 2^224 + 2^192 + 2^96 - 1, backed by a fiat-crypto
+// Montgomery representation. All operations are constant time unless documented otherwise.
+//
+// The zero value is a valid zero element.
+type Element struct {
+	x fiatP256MontgomeryDomainFieldElement
+}
+
+// p256P is the field modulus in little-endian 64-bit limbs.
+const (
+	p256P0 = 0xffffffffffffffff
+	p256P1 = 0x00000000ffffffff
+	p256P2 = 0x0000000000000000
+	p256P3 = 0xffffffff00000001
+)
+
+// One sets e to 1.
+func (e *Element) One() *Element {
+	fiatP256SetOne(&e.x)
+	return e
+}
+
+// Set sets e to x.
+func (e *Element) Set(x *Element) *Element {
+	e.x = x.x
+	return e
+}
+
+// SetInt64 sets e to v mod p. It runs in time dependent on the sign of v.
+func (e *Element) SetInt64(v int64) *Element {
+	var t fiatP256NonMontgomeryDomainFieldElement
+	if v < 0 {
+		t[0] = uint64(-v)
+	} else {
+		t[0] = uint64(v)
+	}
+	fiatP256ToMontgomery(&e.x, &t)
+	if v < 0 {
+		e.Neg(e)
+	}
+	return e
+}
+
+// Bytes returns the 32-byte big-endian encoding of e.
+func (e *Element) Bytes() []byte {
+	// This function is outlined to make the allocations inline in the caller rather than happen
+	// on the heap.
+	var out [32]byte
+	return e.FillBytes(&out)
+}
+
+// FillBytes sets out to the 32-byte big-endian encoding of e, and returns it as a slice.
+func (e *Element) FillBytes(out *[32]byte) []byte {
+	var t fiatP256NonMontgomeryDomainFieldElement
+	fiatP256FromMontgomery(&t, &e.x)
+	fiatP256ToBytes(out, (*[4]uint64)(&t))
+	invertEndianness(out[:])
+	return out[:]
+}
+
+// SetBytes sets e to the 32-byte big-endian value b, reduced mod p.
+func (e *Element) SetBytes(b *[32]byte) *Element {
+	e.SetCanonicalBytes(b)
+	return e
+}
+
+// SetCanonicalBytes sets e to the 32-byte big-endian value b, reduced mod p, and returns 1 if b was
+// less than p and 0 otherwise.
+func (e *Element) SetCanonicalBytes(b *[32]byte) int {
+	in := *b
+	invertEndianness(in[:])
+
+	var t fiatP256NonMontgomeryDomainFieldElement
+	fiatP256FromBytes((*[4]uint64)(&t), &in)
+
+	// Any 256-bit value is less than 2p, so a single conditional subtraction fully reduces it.
+	var r [4]uint64
+	var borrow uint64
+	r[0], borrow = bits.Sub64(t[0], p256P0, 0)
+	r[1], borrow = bits.Sub64(t[1], p256P1, borrow)
+	r[2], borrow = bits.Sub64(t[2], p256P2, borrow)
+	r[3], borrow = bits.Sub64(t[3], p256P3, borrow)
+	fiatP256Selectznz((*[4]uint64)(&t), fiatP256Uint1(borrow), &r, (*[4]uint64)(&t))
+
+	fiatP256ToMontgomery(&e.x, &t)
+	return int(borrow)
+}
+
+// SetWideBytes sets e to the big-endian value b, which must be at most 64 bytes long, reduced mod
+// p.
+func (e *Element) SetWideBytes(b []byte) *Element {
+	var hi, lo [32]byte
+	n := max(0, len(b)-32)
+	copy(hi[32-n:], b[:n])
+	copy(lo[32-(len(b)-n):], b[n:])
+
+	// e = hi * 2^256 + lo
+	var r Element
+	r.SetBytes(&[32]byte{
+		0x00, 0x00, 0x00, 0x00, 0xff, 0xff, 0xff, 0xfe, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+		0xff, 0xff, 0xff, 0xff, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01,
+	})
+	e.SetBytes(&hi)
+	e.Mul(e, &r)
+	return e.Add(e, r.SetBytes(&lo))
+}
+
+// Lift sets out to the 32-byte big-endian encoding of e + kp, for k \in {0, 1}, and returns 1 if
+// the result is less than 2^256 and 0 otherwise.
+func (e *Element) Lift(out *[32]byte, k int) int {
+	var t fiatP256NonMontgomeryDomainFieldElement
+	fiatP256FromMontgomery(&t, &e.x)
+
+	mask := -uint64(k)
+	var carry uint64
+	t[0], carry = bits.Add64(t[0], p256P0&mask, 0)
+	t[1], carry = bits.Add64(t[1], p256P1&mask, carry)
+	t[2], carry = bits.Add64(t[2], p256P2&mask, carry)
+	t[3], carry = bits.Add64(t[3], p256P3&mask, carry)
+
+	fiatP256ToBytes(out, (*[4]uint64)(&t))
+	invertEndianness(out[:])
+	return int(1 ^ carry)
+}
+
+// String returns the hex encoding of e.
+func (e *Element) String() string {
+	return hex.EncodeToString(e.Bytes())
+}
+
+// SetString sets e to the hex-encoded 32-byte big-endian value s, reduced mod p. It panics if s is
+// not valid hex, and is intended for constants and tests.
+func (e *Element) SetString(s string) *Element {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return e.SetBytes((*[32]byte)(b))
+}
+
+// Add sets e to x + y.
+func (e *Element) Add(x, y *Element) *Element {
+	fiatP256Add(&e.x, &x.x, &y.x)
+	return e
+}
+
+// Sub sets e to x - y.
+func (e *Element) Sub(x, y *Element) *Element {
+	fiatP256Sub(&e.x, &x.x, &y.x)
+	return e
+}
+
+// Mul sets e to x * y.
+func (e *Element) Mul(x, y *Element) *Element {
+	fiatP256Mul(&e.x, &x.x, &y.x)
+	return e
+}
+
+// Square sets e to x * x.
+func (e *Element) Square(x *Element) *Element {
+	fiatP256Square(&e.x, &x.x)
+	return e
+}
+
+// Exp sets e to x^y. It runs in time dependent on y, which must be public and non-negative.
+func (e *Element) Exp(x *Element, y int64) *Element {
+	var z Element
+	z.One()
+	for i := 62; i >= 0; i-- {
+		z.Square(&z)
+		if (y>>i)&1 == 1 {
+			z.Mul(&z, x)
+		}
+	}
+	return e.Set(&z)
+}
+
+// Neg sets e to -x.
+func (e *Element) Neg(x *Element) *Element {
+	var zero fiatP256MontgomeryDomainFieldElement
+	fiatP256Sub(&e.x, &zero, &x.x)
+	return e
+}
+
+// Select sets e to a if cond == 1, and to b if cond == 0.
+func (e *Element) Select(a, b *Element, cond int) *Element {
+	fiatP256Selectznz((*[4]uint64)(&e.x), fiatP256Uint1(cond), (*[4]uint64)(&b.x), (*[4]uint64)(&a.x))
+	return e
+}
+
+// Equal returns 1 if e == x, and 0 otherwise.
+func (e *Element) Equal(x *Element) int {
+	var d uint64
+	for i := range e.x {
+		d |= e.x[i] ^ x.x[i]
+	}
+	return isZeroWord(d)
+}
+
+// IsZero returns 1 if e == 0, and 0 otherwise.
+func (e *Element) IsZero() int {
+	var d uint64
+	for i := range e.x {
+		d |= e.x[i]
+	}
+	return isZeroWord(d)
+}
+
+// BatchInvert sets each element of es to its inverse using Montgomery's trick, which costs a single
+// inversion and 3(n-1) multiplications. As with Invert, zero elements are set to zero. scratch must
+// be at least as long as es. It runs in constant time.
+func BatchInvert(es, scratch []Element) {
+	if len(es) == 0 {
+		return
+	}
+
+	// scratch[i] = es[0] * ... * es[i], with zeros replaced by ones so they don't zero out the
+	// running product.
+	var one, zero, t Element
+	one.One()
+	scratch[0].Select(&one, &es[0], es[0].IsZero())
+	for i := 1; i < len(es); i++ {
+		t.Select(&one, &es[i], es[i].IsZero())
+		scratch[i].Mul(&scratch[i-1], &t)
+	}
+
+	var inv, r Element
+	inv.Invert(&scratch[len(es)-1])
+	for i := len(es) - 1; i > 0; i-- {
+		isZero := es[i].IsZero()
+		t.Select(&one, &es[i], isZero)
+		r.Mul(&inv, &scratch[i-1])
+		inv.Mul(&inv, &t)
+		es[i].Select(&zero, &r, isZero)
+	}
+	es[0].Select(&zero, &inv, es[0].IsZero())
+}
+
+// Invert sets e to 1/x. If x == 0, Invert sets e to 0.
+func (e *Element) Invert(x *Element) *Element {
+	// Inversion is implemented as exponentiation with exponent p − 2.
+	// The sequence of 12 multiplications and 255 squarings is derived from the
+	// following addition chain generated with github.com/mmcloughlin/addchain v0.4.0.
+	//
+	//	_10     = 2*1
+	//	_11     = 1 + _10
+	//	_110    = 2*_11
+	//	_111    = 1 + _110
+	//	_111000 = _111 << 3
+	//	_111111 = _111 + _111000
+	//	x12     = _111111 << 6 + _111111
+	//	x15     = x12 << 3 + _111
+	//	x16     = 2*x15 + 1
+	//	x32     = x16 << 16 + x16
+	//	i53     = x32 << 15
+	//	x47     = x15 + i53
+	//	i263    = ((i53 << 17 + 1) << 143 + x47) << 47
+	//	return    (x47 + i263) << 2 + 1
+	//
+	var z, t0, t1 Element
+
+	z.Square(x)
+	z.Mul(x, &z)
+	z.Square(&z)
+	z.Mul(x, &z)
+	t0.squareN(&z, 3)
+	t0.Mul(&z, &t0)
+	t1.squareN(&t0, 6)
+	t0.Mul(&t0, &t1)
+	t0.squareN(&t0, 3)
+	z.Mul(&z, &t0)
+	t0.Square(&z)
+	t0.Mul(x, &t0)
+	t1.squareN(&t0, 16)
+	t0.Mul(&t0, &t1)
+	t0.squareN(&t0, 15)
+	z.Mul(&z, &t0)
+	t0.squareN(&t0, 17)
+	t0.Mul(x, &t0)
+	t0.squareN(&t0, 143)
+	t0.Mul(&z, &t0)
+	t0.squareN(&t0, 47)
+	z.Mul(&z, &t0)
+	z.squareN(&z, 2)
+	z.Mul(x, &z)
+
+	return e.Set(&z)
+}
+
+// Sqrt sets e to the square root of x and returns e. If x is not a square, Sqrt returns nil and e
+// is unchanged. Sqrt runs in time dependent only on whether x is a square.
+func (e *Element) Sqrt(x *Element) *Element {
+	var t Element
+	if t.SqrtCandidate(x) != 1 {
+		return nil
+	}
+	return e.Set(&t)
+}
+
+// SqrtCandidate sets e to x^((p+1)/4), which is a square root of x if one exists, and returns 1 if
+// x is a square and 0 otherwise. e and x may overlap.
+func (e *Element) SqrtCandidate(x *Element) int {
+	// Since p = 3 mod 4, exponentiation by (p + 1) / 4 yields a square root candidate.
+	//
+	// The sequence of 7 multiplications and 253 squarings is derived from the
+	// following addition chain generated with github.com/mmcloughlin/addchain v0.4.0.
+	//
+	//	_10       = 2*1
+	//	_11       = 1 + _10
+	//	_1100     = _11 << 2
+	//	_1111     = _11 + _1100
+	//	_11110000 = _1111 << 4
+	//	_11111111 = _1111 + _11110000
+	//	x16       = _11111111 << 8 + _11111111
+	//	x32       = x16 << 16 + x16
+	//	return      ((x32 << 32 + 1) << 96 + 1) << 94
+	//
+	var t0, t1 Element
+	t0.Square(x)
+	t0.Mul(x, &t0)
+	t1.squareN(&t0, 2)
+	t0.Mul(&t0, &t1)
+	t1.squareN(&t0, 4)
+	t0.Mul(&t0, &t1)
+	t1.squareN(&t0, 8)
+	t0.Mul(&t0, &t1)
+	t1.squareN(&t0, 16)
+	t0.Mul(&t0, &t1)
+	t0.squareN(&t0, 32)
+	t0.Mul(x, &t0)
+	t0.squareN(&t0, 96)
+	t0.Mul(x, &t0)
+	t0.squareN(&t0, 94)
+
+	// Check if the candidate t0 is indeed a square root of x.
+	t1.Square(&t0)
+	isSquare := t1.Equal(x)
+	e.Set(&t0)
+	return isSquare
+}
+
+// SetA sets e to A = -3.
+func (e *Element) SetA() *Element {
+	return e.SetInt64(-3)
+}
+
+// SetB sets e to B.
+func (e *Element) SetB() *Element {
+	return e.SetBytes(&[32]byte{
+		0x5a, 0xc6, 0x35, 0xd8, 0xaa, 0x3a, 0x93, 0xe7, 0xb3, 0xeb, 0xbd, 0x55, 0x76, 0x98, 0x86, 0xbc,
+		0x65, 0x1d, 0x06, 0xb0, 0xcc, 0x53, 0xb0, 0xf6, 0x3b, 0xce, 0x3c, 0x3e, 0x27, 0xd2, 0x60, 0x4b,
+	})
+}
+
+// SetNegBOverA sets e to -B/A.
+func (e *Element) SetNegBOverA() *Element {
+	return e.SetBytes(&[32]byte{
+		0x73, 0x97, 0x67, 0x47, 0xe3, 0x68, 0xdb, 0xf8, 0x3b, 0xf9, 0x3f, 0x1c, 0x7c, 0xdd, 0x82, 0x3e,
+		0xcc, 0x5f, 0x02, 0x3b, 0x44, 0x1b, 0xe5, 0xa7, 0x69, 0x44, 0xbe, 0xbf, 0x62, 0x9b, 0x75, 0x6e,
+	})
+}
+
+// SetAOverB sets e to A/B.
+func (e *Element) SetAOverB() *Element {
+	return e.SetBytes(&[32]byte{
+		0xfd, 0x25, 0x1a, 0xce, 0xdd, 0xf5, 0xd6, 0xed, 0x47, 0x3c, 0x5e, 0xde, 0x08, 0x4c, 0x2f, 0x36,
+		0xde, 0xaa, 0x32, 0xac, 0x7b, 0x70, 0x7d, 0x42, 0x01, 0xbc, 0xf7, 0x80, 0x20, 0xca, 0x73, 0x0e,
+	})
+}
+
+// SetBOverZA sets e to B/(ZA), where Z = -10 is the RFC 9380 Simplified SWU constant for P-256.
+func (e *Element) SetBOverZA() *Element {
+	return e.SetBytes(&[32]byte{
+		0xa5, 0x28, 0xbd, 0x86, 0x96, 0xbd, 0xaf, 0x99, 0x6c, 0x65, 0xb9, 0x82, 0xd9, 0x49, 0x59, 0xd3,
+		0x14, 0x6f, 0xe6, 0xa0, 0x20, 0x69, 0x30, 0x90, 0xbd, 0xba, 0x13, 0x13, 0x23, 0x75, 0xf2, 0x24,
+	})
+}
+
+// SetInvTwoZ sets e to 1/(2Z), where Z = -10 is the RFC 9380 Simplified SWU constant for P-256.
+func (e *Element) SetInvTwoZ() *Element {
+	return e.SetBytes(&[32]byte{
+		0x8c, 0xcc, 0xcc, 0xcc, 0x40, 0x00, 0x00, 0x00, 0x8c, 0xcc, 0xcc, 0xcc, 0xcc, 0xcc, 0xcc, 0xcc,
+		0xcc, 0xcc, 0xcc, 0xcd, 0x59, 0x99, 0x99, 0x99, 0x99, 0x99, 0x99, 0x99, 0x99, 0x99, 0x99, 0x99,
+	})
+}
+
+// squareN sets e to x squared n times, for n >= 1.
+func (e *Element) squareN(x *Element, n int) *Element {
+	e.Square(x)
+	for range n - 1 {
+		e.Square(e)
+	}
+	return e
+}
+
+// isZeroWord returns 1 if w == 0, and 0 otherwise, in constant time.
+func isZeroWord(w uint64) int {
+	return int(1 ^ ((w | -w) >> 63))
+}
+
+// invertEndianness reverses the order of the bytes in v.
+func invertEndianness(v []byte) {
+	for i := range len(v) / 2 {
+		v[i], v[len(v)-1-i] = v[len(v)-1-i], v[i]
+	}
+}
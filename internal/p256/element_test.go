@@ -0,0 +1,341 @@
+package p256
+
+import (
+	"bytes"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"testing"
+)
+
+func TestFeInvert(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		x, want *Element
+	}{
+		{
+			x:    new(Element).SetString("1fdabb681a533e5c40a2bd8a41cce53e00dac69911cbcb15c015998a56e17470"),
+			want: new(Element).SetString("64862d9e85146e22bf10ec835a375238bfb8ba45bbca12a11d236dec34e85bf0"),
+		},
+		{
+			x:    new(Element).SetString("fca4003dbd57560c1a480d2ee3b2badc5b53eaafc175b5d6f067468133853ecc"),
+			want: new(Element).SetString("77c09e9aee85123775e4339fb0e0fbea811c6dd0f03c043be32aaaf79317cfa2"),
+		},
+		{
+			x:    new(Element).SetString("4473dc50155ac13645750235bcef87342eb4a83a5f53e3bd1de903fbc9deb35c"),
+			want: new(Element).SetString("101a587ec25377af1a54285e4e4cdfeb46ccfff17824c7836f9d853b6ee1f9ad"),
+		},
+		{
+			x:    new(Element).SetString("7db0365ebf272f717872d511e8a513c1566365aa9adb45fa5a828b3172a99fac"),
+			want: new(Element).SetString("00c1657e6f821eece6a435b1065e844094e32ba56489cd3d13188b8a147289a1"),
+		},
+		{
+			x:    new(Element).SetString("37afad3e25c250b547f9029c1ac5f2a6e3b0159493f000668ed7998a0041ba03"),
+			want: new(Element).SetString("2291fdf3fe3abb9a6dfc624a6a1835c67a37de4581690fc949ae4f8f19e2a755"),
+		},
+		{
+			x:    new(Element).SetString("347a7a7de806697603d45e9b8a6771d078ad5333ca2e4c9ce369d0a6b46e9b9d"),
+			want: new(Element).SetString("75d506bae18923872af8f434bc73d55420269b732b2fa31cc695015462e3ebd8"),
+		},
+		{
+			x:    new(Element).SetString("2e5aa9db5ca7e78a7f5223fedb0a7a895d54722345692a0938b2f8a93e9ccf73"),
+			want: new(Element).SetString("eb0176137c6651d9bc314f451ba7fd7882c19ff9b5e5f59652b6397e8bdb3ac5"),
+		},
+		{
+			x:    new(Element).SetString("4e597cf5994a0393cfab4b6e0e7392eeca409ba1cce62d9dd74d9ea64115b65a"),
+			want: new(Element).SetString("4c89c2b1448ac9122b9241d6aa6c22beed5e1037947ef57fe688d480568857b4"),
+		},
+		{
+			x:    new(Element).SetString("d432871dfdd5d01569a163a35e12a40ab46da4d1a3b9c65cfae4e7bd654211b7"),
+			want: new(Element).SetString("fbc4beb3d5fe003822989c290b30a195bf16cf8d7e05e6fc5ee6e7bdcd154078"),
+		},
+		{
+			x:    new(Element).SetString("ca20b1014fceafeb71b7d86e58b8d5caa86f8059f3218edf85251b84470cef1b"),
+			want: new(Element).SetString("3b47c7acb2ce7e9d9747f6402112d4f2f9c9e77e379d0aa4240dc05c0336e49c"),
+		},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("feInvert(%s)", test.x), func(t *testing.T) {
+			t.Parallel()
+
+			if got, want := new(Element).Invert(test.x), test.want; got.Equal(want) != 1 {
+				t.Errorf("feInvert(%s) = %s, want = %s", test.x, got, want)
+			}
+		})
+	}
+}
+
+func TestFeSqrt(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		x, want *Element
+	}{
+		{
+			x:    new(Element).SetString("743a004100e76a1de51b190d316eda1dbb6d2b9bb1082aca0034a168f8fc9461"),
+			want: nil,
+		},
+		{
+			x:    new(Element).SetString("f6c0af4f1d2e6e86194f4711d1edbfa07329d7886faf4396607323b0af186734"),
+			want: nil,
+		},
+		{
+			x:    new(Element).SetString("bec1e5a7c5ce5d08c1b0d3301e86ef5fec1a2ccec305e22e1b7aec5bf4845809"),
+			want: new(Element).SetString("6b60f243c48bb13408ea83d48e93dd82909ff2e68dd0270eda858248962b9d9a"),
+		},
+		{
+			x:    new(Element).SetString("80b8325a8df5a1921035272ef2a580833cb492244f2cb536071a2b482a81d016"),
+			want: nil,
+		},
+		{
+			x:    new(Element).SetString("23f01c63fd3aff5940c48319417eb316bd5b7aa9add204a31604dd9c81368bc6"),
+			want: nil,
+		},
+		{
+			x:    new(Element).SetString("95dec40812c0df5e50368e2fe9b73c4775c9819aaf4e5612190dcf90a1a4da19"),
+			want: new(Element).SetString("b9ae368667f9e5a4defbd9e1b2bede87a179c48a065e36314d3c7a47c8d9d111"),
+		},
+		{
+			x:    new(Element).SetString("df1ae93085b744df0e4ac8a0e9b00aa34ae2e5ecf43716dd12d603d66dec1218"),
+			want: new(Element).SetString("0e1449c0d2e8e282f6e15ced0828476594298db2dc9b83cac4c7fbc1567060d3"),
+		},
+		{
+			x:    new(Element).SetString("2c7ea58b58661a80e94aab235c3da563ca02a7ea9f003b518a409fc9c313eb42"),
+			want: nil,
+		},
+		{
+			x:    new(Element).SetString("4121db9b0c5649e16b516c83393366ed98f40a30f0907abc94c3bea326608252"),
+			want: new(Element).SetString("3e4cda1fd6e27c9407a8498c69812fbaee24bfed9c7aba30572f24b1089f3919"),
+		},
+		{
+			x:    new(Element).SetString("09fd2028bfb2cf2bb1ca8ea13e0580243541665f0db25520464afe813332ed78"),
+			want: nil,
+		},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("feSqrt(%s)", test.x), func(t *testing.T) {
+			t.Parallel()
+
+			got, want := new(Element).Sqrt(test.x), test.want
+			switch {
+			case got != nil && want == nil:
+				t.Errorf("feSqrt(%s) = %s, want nil", test.x, got)
+			case got == nil && want != nil:
+				t.Errorf("feSqrt(%s) = nil, want %s", test.x, want)
+			case got != nil && want != nil && got.Equal(want) != 1:
+				t.Errorf("feSqrt(%s) = %s, want = %s", test.x, got, want)
+			}
+		})
+	}
+}
+
+func TestFieldElementOracle(t *testing.T) {
+	t.Parallel()
+
+	inputs := [][]byte{
+		make([]byte, 32),
+		bytes.Repeat([]byte{0xff}, 32),
+		new(Element).SetInt64(1).Bytes(),
+		new(Element).SetInt64(-1).Bytes(),
+		elliptic.P256().Params().P.Bytes(),
+	}
+	for range 100 {
+		b := make([]byte, 32)
+		if _, err := rand.Read(b); err != nil {
+			t.Fatal(err)
+		}
+		inputs = append(inputs, b)
+	}
+
+	for i, a := range inputs {
+		b := inputs[(i+1)%len(inputs)]
+		x, y := new(Element).SetBytes((*[32]byte)(a)), new(Element).SetBytes((*[32]byte)(b))
+		ox, oy := new(oracleElement).SetBytes(a), new(oracleElement).SetBytes(b)
+
+		check := func(op string, got *Element, want *oracleElement) {
+			t.Helper()
+			if !bytes.Equal(got.Bytes(), want.Bytes()) {
+				t.Errorf("%s(%x, %x) = %s, want = %x", op, a, b, got, want.Bytes())
+			}
+		}
+
+		check("SetBytes", x, ox)
+		for _, n := range []int{16, 48, 64} {
+			wide := append(bytes.Clone(a), b...)[:n]
+			check("SetWideBytes", new(Element).SetWideBytes(wide), new(oracleElement).SetBytes(wide))
+		}
+		check("Add", new(Element).Add(x, y), new(oracleElement).Add(ox, oy))
+		check("Sub", new(Element).Sub(x, y), new(oracleElement).Sub(ox, oy))
+		check("Mul", new(Element).Mul(x, y), new(oracleElement).Mul(ox, oy))
+		check("Square", new(Element).Square(x), new(oracleElement).Mul(ox, ox))
+		check("Exp", new(Element).Exp(x, 3), new(oracleElement).Exp(ox, 3))
+		check("Neg", new(Element).Neg(x), new(oracleElement).Neg(ox))
+		check("Invert", new(Element).Invert(x), new(oracleElement).Invert(ox))
+
+		got, want := new(Element).Sqrt(x), new(oracleElement).Sqrt(ox)
+		switch {
+		case (got == nil) != (want == nil):
+			t.Errorf("Sqrt(%x) = %v, want = %v", a, got, want)
+		case got != nil:
+			check("Sqrt", got, want)
+		}
+	}
+}
+
+func TestFeLift(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		x    *Element
+		k    int
+		want string
+		ok   int
+	}{
+		{
+			x:    new(Element).SetInt64(1),
+			k:    0,
+			want: "0000000000000000000000000000000000000000000000000000000000000001",
+			ok:   1,
+		},
+		{
+			x:    new(Element).SetInt64(1),
+			k:    1,
+			want: "ffffffff00000001000000000000000000000001000000000000000000000000",
+			ok:   1,
+		},
+		{
+			x:    new(Element).SetInt64(-1),
+			k:    0,
+			want: "ffffffff00000001000000000000000000000000fffffffffffffffffffffffe",
+			ok:   1,
+		},
+		{
+			x:  new(Element).SetInt64(-1),
+			k:  1,
+			ok: 0,
+		}, {
+			// 2^256 - p - 1 is the largest value which can be lifted.
+			x:    new(Element).SetString("00000000fffffffeffffffffffffffffffffffff000000000000000000000000"),
+			k:    1,
+			want: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+			ok:   1,
+		},
+		{
+			// 2^256 - p is the smallest value which cannot.
+			x:  new(Element).SetString("00000000fffffffeffffffffffffffffffffffff000000000000000000000001"),
+			k:  1,
+			ok: 0,
+		},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("feLift(%s, %d)", test.x, test.k), func(t *testing.T) {
+			t.Parallel()
+
+			var out [32]byte
+			ok := test.x.Lift(&out, test.k)
+			if ok != test.ok {
+				t.Fatalf("feLift(%s, %d) ok = %d, want = %d", test.x, test.k, ok, test.ok)
+			}
+
+			if ok == 1 {
+				if got := hex.EncodeToString(out[:]); got != test.want {
+					t.Errorf("feLift(%s, %d) = %s, want = %s", test.x, test.k, got, test.want)
+				}
+
+				if got := new(Element).SetBytes(&out); got.Equal(test.x) != 1 {
+					t.Errorf("SetBytes(feLift(%s, %d)) = %s, want = %s", test.x, test.k, got, test.x)
+				}
+			}
+		})
+	}
+}
+
+func TestBatchInvert(t *testing.T) {
+	t.Parallel()
+
+	for _, zeros := range [][]int{{}, {0}, {3}, {7}, {0, 7}, {0, 1, 2, 3, 4, 5, 6, 7}} {
+		es := make([]Element, 8)
+		for i := range es {
+			es[i].SetInt64(int64(i + 2))
+		}
+		for _, i := range zeros {
+			es[i] = Element{}
+		}
+		want := make([]Element, len(es))
+		for i := range es {
+			want[i].Invert(&es[i])
+		}
+
+		BatchInvert(es, make([]Element, len(es)))
+		for i := range es {
+			if es[i].Equal(&want[i]) != 1 {
+				t.Errorf("BatchInvert(zeros=%v)[%d] = %s, want = %s", zeros, i, &es[i], &want[i])
+			}
+		}
+	}
+}
+
+// oracleElement is the original math/big-backed field element, kept as a variable-time reference
+// implementation for testing Element.
+type oracleElement struct {
+	v big.Int
+}
+
+func (e *oracleElement) Bytes() []byte {
+	var bytes [32]byte
+	e.v.FillBytes(bytes[:])
+	return bytes[:]
+}
+
+func (e *oracleElement) SetBytes(b []byte) *oracleElement {
+	e.v.SetBytes(b)
+	e.v.Mod(&e.v, elliptic.P256().Params().P)
+	return e
+}
+
+func (e *oracleElement) Add(x, y *oracleElement) *oracleElement {
+	e.v.Add(&x.v, &y.v).Mod(&e.v, elliptic.P256().Params().P)
+	return e
+}
+
+func (e *oracleElement) Sub(x, y *oracleElement) *oracleElement {
+	e.v.Sub(&x.v, &y.v).Mod(&e.v, elliptic.P256().Params().P)
+	return e
+}
+
+func (e *oracleElement) Mul(x, y *oracleElement) *oracleElement {
+	e.v.Mul(&x.v, &y.v).Mod(&e.v, elliptic.P256().Params().P)
+	return e
+}
+
+func (e *oracleElement) Exp(x *oracleElement, y int64) *oracleElement {
+	e.v.Exp(&x.v, big.NewInt(y), elliptic.P256().Params().P)
+	return e
+}
+
+func (e *oracleElement) Neg(x *oracleElement) *oracleElement {
+	e.v.Neg(&x.v).Mod(&e.v, elliptic.P256().Params().P)
+	return e
+}
+
+func (e *oracleElement) Invert(x *oracleElement) *oracleElement {
+	if x.v.Sign() == 0 {
+		e.v.SetInt64(0)
+		return e
+	}
+	e.v.ModInverse(&x.v, elliptic.P256().Params().P)
+	return e
+}
+
+func (e *oracleElement) Sqrt(x *oracleElement) *oracleElement {
+	var candidate oracleElement
+	candidate.v.ModSqrt(&x.v, elliptic.P256().Params().P)
+	if new(oracleElement).Exp(&candidate, 2).v.Cmp(&x.v) != 0 {
+		return nil
+	}
+	*e = candidate
+	return e
+}
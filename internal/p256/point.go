@@ -0,0 +1,189 @@
+package p256
+
+import "slices"
+
+// G sets e to g(x) = x^3 + Ax + B, the right-hand side of the curve equation y^2 = g(x), and
+// returns e. e may overlap with x.
+func G(e, x *Element) *Element {
+	var y, threeX Element
+
+	// x^3
+	y.Square(x)
+	y.Mul(&y, x)
+
+	// -3x
+	threeX.Add(x, x)
+	threeX.Add(&threeX, x)
+	y.Sub(&y, &threeX)
+
+	// B
+	return e.Add(&y, new(Element).SetB())
+}
+
+// Generator sets (x, y) to the P-256 base point G.
+func Generator(x, y *Element) {
+	x.SetBytes(&[32]byte{
+		0x6b, 0x17, 0xd1, 0xf2, 0xe1, 0x2c, 0x42, 0x47, 0xf8, 0xbc, 0xe6, 0xe5, 0x63, 0xa4, 0x40, 0xf2,
+		0x77, 0x03, 0x7d, 0x81, 0x2d, 0xeb, 0x33, 0xa0, 0xf4, 0xa1, 0x39, 0x45, 0xd8, 0x98, 0xc2, 0x96,
+	})
+	y.SetBytes(&[32]byte{
+		0x4f, 0xe3, 0x42, 0xe2, 0xfe, 0x1a, 0x7f, 0x9b, 0x8e, 0xe7, 0xeb, 0x4a, 0x7c, 0x0f, 0x9e, 0x16,
+		0x2b, 0xce, 0x33, 0x57, 0x6b, 0x31, 0x5e, 0xce, 0xcb, 0xb6, 0x40, 0x68, 0x37, 0xbf, 0x51, 0xf5,
+	})
+}
+
+// Add sets (x3, y3) to the sum of the affine points (x1, y1) and (x2, y2). The point at
+// infinity is represented as (0, 0). The output may overlap with the inputs.
+func Add(x3, y3, x1, y1, x2, y2 *Element) {
+	var z Element
+	AddProjective(x3, y3, &z, x1, y1, x2, y2)
+
+	// Convert back to affine. The inverse of zero is zero, so infinity maps to (0, 0).
+	z.Invert(&z)
+	x3.Mul(x3, &z)
+	y3.Mul(y3, &z)
+}
+
+// AddProjective sets (x3 : y3 : z3) to the sum of the affine points (x1, y1) and (x2, y2) in
+// projective coordinates. The point at infinity is represented as (0, 0) in the inputs and has
+// z3 = 0 in the output. The output may overlap with the inputs.
+func AddProjective(x3, y3, z3, x1, y1, x2, y2 *Element) {
+	// Convert to projective, mapping (0, 0) to the point at infinity (0 : 1 : 0) without branching.
+	var py1, pz1, py2, pz2 Element
+	toProjective(&py1, &pz1, x1, y1)
+	toProjective(&py2, &pz2, x2, y2)
+	AddComplete(x3, y3, z3, x1, &py1, &pz1, x2, &py2, &pz2)
+}
+
+// toProjective sets (x : py : pz) to the affine point (x, y), mapping (0, 0) to the point at
+// infinity (0 : 1 : 0). (0 : 0 : 0) is not a point, and the complete formulas return it for any sum
+// with it.
+func toProjective(py, pz, x, y *Element) {
+	var one Element
+	one.One()
+	inf := x.IsZero() & y.IsZero()
+	py.Select(&one, y, inf)
+	pz.Select(new(Element), &one, inf)
+}
+
+// AddComplete sets (x3 : y3 : z3) to the sum of the projective points (x1 : y1 : z1) and
+// (x2 : y2 : z2). It handles doubling and the point at infinity (z = 0) without special cases. The
+// output may overlap with the inputs.
+//
+//nolint:funlen // this is just complicated, man
+func AddComplete(x3, y3, z3, x1, y1, z1, x2, y2, z2 *Element) {
+	// Complete addition formula for a = -3 from "Complete addition formulas for
+	// prime order elliptic curves" (https://eprint.iacr.org/2015/1060), §A.2.
+	var t0, t1, t2, t3, t4, x, y, z, b Element
+	t0.Mul(x1, x2)   // t0 := X1 * X2
+	t1.Mul(y1, y2)   // t1 := Y1 * Y2
+	t2.Mul(z1, z2)   // t2 := Z1 * Z2
+	t3.Add(x1, y1)   // t3 := X1 + Y1
+	t4.Add(x2, y2)   // t4 := X2 + Y2
+	t3.Mul(&t3, &t4) // t3 := t3 * t4
+	t4.Add(&t0, &t1) // t4 := t0 + t1
+	t3.Sub(&t3, &t4) // t3 := t3 - t4
+	t4.Add(y1, z1)   // t4 := Y1 + Z1
+	x.Add(y2, z2)    // X3 := Y2 + Z2
+	t4.Mul(&t4, &x)  // t4 := t4 * X3
+	x.Add(&t1, &t2)  // X3 := t1 + t2
+	t4.Sub(&t4, &x)  // t4 := t4 - X3
+	x.Add(x1, z1)    // X3 := X1 + Z1
+	y.Add(x2, z2)    // Y3 := X2 + Z2
+	x.Mul(&x, &y)    // X3 := X3 * Y3
+	y.Add(&t0, &t2)  // Y3 := t0 + t2
+	y.Sub(&x, &y)    // Y3 := X3 - Y3
+	b.SetB()
+	z.Mul(&b, &t2)   // Z3 := b * t2
+	x.Sub(&y, &z)    // X3 := Y3 - Z3
+	z.Add(&x, &x)    // Z3 := X3 + X3
+	x.Add(&x, &z)    // X3 := X3 + Z3
+	z.Sub(&t1, &x)   // Z3 := t1 - X3
+	x.Add(&t1, &x)   // X3 := t1 + X3
+	y.Mul(&b, &y)    // Y3 := b * Y3
+	t1.Add(&t2, &t2) // t1 := t2 + t2
+	t2.Add(&t1, &t2) // t2 := t1 + t2
+	y.Sub(&y, &t2)   // Y3 := Y3 - t2
+	y.Sub(&y, &t0)   // Y3 := Y3 - t0
+	t1.Add(&y, &y)   // t1 := Y3 + Y3
+	y.Add(&t1, &y)   // Y3 := t1 + Y3
+	t1.Add(&t0, &t0) // t1 := t0 + t0
+	t0.Add(&t1, &t0) // t0 := t1 + t0
+	t0.Sub(&t0, &t2) // t0 := t0 - t2
+	t1.Mul(&t4, &y)  // t1 := t4 * Y3
+	t2.Mul(&t0, &y)  // t2 := t0 * Y3
+	y.Mul(&x, &z)    // Y3 := X3 * Z3
+	y.Add(&y, &t2)   // Y3 := Y3 + t2
+	x.Mul(&t3, &x)   // X3 := t3 * X3
+	x.Sub(&x, &t1)   // X3 := X3 - t1
+	z.Mul(&t4, &z)   // Z3 := t4 * Z3
+	t1.Mul(&t3, &t0) // t1 := t3 * t0
+	z.Add(&z, &t1)   // Z3 := Z3 + t1
+
+	x3.Set(&x)
+	y3.Set(&y)
+	z3.Set(&z)
+}
+
+// ScalarMult sets (x3, y3) to k(x1, y1) using a constant-time double-and-add over every bit of k.
+// The point at infinity is represented as (0, 0). The output may overlap with the inputs.
+func ScalarMult(x3, y3, x1, y1 *Element, k *Scalar) {
+	var kb [32]byte
+	k.FillBytes(&kb)
+
+	var px, py, pz, rx, ry, rz, tx, ty, tz Element
+	px.Set(x1)
+	toProjective(&py, &pz, x1, y1)
+	ry.One() // (0 : 1 : 0) is the point at infinity.
+	for _, b := range kb {
+		for i := 7; i >= 0; i-- {
+			AddComplete(&rx, &ry, &rz, &rx, &ry, &rz, &rx, &ry, &rz)
+			AddComplete(&tx, &ty, &tz, &rx, &ry, &rz, &px, &py, &pz)
+
+			bit := int(b>>i) & 1
+			rx.Select(&tx, &rx, bit)
+			ry.Select(&ty, &ry, bit)
+			rz.Select(&tz, &rz, bit)
+		}
+	}
+
+	// Convert back to affine. The inverse of zero is zero, so infinity maps to (0, 0).
+	rz.Invert(&rz)
+	x3.Mul(&rx, &rz)
+	y3.Mul(&ry, &rz)
+}
+
+// ScalarBaseMult sets (x, y) to kG, where G is the base point. It runs in constant time.
+func ScalarBaseMult(x, y *Element, k *Scalar) {
+	var gx, gy Element
+	Generator(&gx, &gy)
+	ScalarMult(x, y, &gx, &gy, k)
+}
+
+// Decompress sets (x, y) to the point with the 33-byte compressed SEC encoding b, and returns 1 if
+// b is a valid encoding of a point on the curve and 0 otherwise.
+func Decompress(x, y *Element, b *[33]byte) int {
+	if b[0] != 2 && b[0] != 3 {
+		return 0
+	}
+
+	ok := x.SetCanonicalBytes((*[32]byte)(b[1:]))
+
+	// y² = x³ - 3x + b
+	ok &= y.SqrtCandidate(G(y, x))
+
+	// Select the positive or negative root, as indicated by the least significant bit, based on
+	// the encoding type byte.
+	var otherRoot Element
+	otherRoot.Neg(y)
+	y.Select(&otherRoot, y, int(y.Bytes()[31]&1^b[0]&1))
+	return ok
+}
+
+// AppendCompressed appends the 33-byte compressed SEC encoding of (x, y) to dst.
+func AppendCompressed(dst []byte, x, y *Element) []byte {
+	var buf [32]byte
+	dst = slices.Grow(dst, 33)
+	dst = append(dst, 2|y.FillBytes(&buf)[31]&1)
+	return append(dst, x.FillBytes(&buf)...)
+}
@@ -0,0 +1,286 @@
+package p256
+
+import (
+	"bytes"
+	"crypto/ecdh"
+	"crypto/elliptic"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"testing"
+)
+
+func TestG(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		x, want *Element
+	}{
+		{
+			x:    new(Element).SetInt64(1),
+			want: new(Element).SetString("5ac635d8aa3a93e7b3ebbd55769886bc651d06b0cc53b0f63bce3c3e27d26049"),
+		},
+		{
+			x:    new(Element).SetInt64(2),
+			want: new(Element).SetString("5ac635d8aa3a93e7b3ebbd55769886bc651d06b0cc53b0f63bce3c3e27d2604d"),
+		},
+		{
+			x:    new(Element).SetString("4077f2bde92bfa027151a7412d6e92ba0c035eb58dc8c86b4f659536c36b47d5"),
+			want: new(Element).SetString("2819ec852c134ff7a481d7adbc3f1a085bc9f6b250a5917a822703f191f3ea4d"),
+		},
+		{
+			x:    new(Element).SetString("dbf9ace2b5d50a2974d1227c37571235055b3ceccc5b075d0a7dccb571a0e497"),
+			want: new(Element).SetString("72bde2e2f464bbcb043d01e6901f8949b90a9167775cf278990a1a31d321a691"),
+		},
+		{
+			x:    new(Element).SetString("49a25b63783bc98313dc9590892d74a4e6ef2daac04910d9a84ba0c45f62ba37"),
+			want: new(Element).SetString("58e94d66dc3b8bb3a6e07fa7998e38084b9a374eb4da29b70d26f7c77531b287"),
+		},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("G(%s)", test.x), func(t *testing.T) {
+			t.Parallel()
+			if got, want := G(new(Element), test.x), test.want; got.Equal(want) != 1 {
+				t.Errorf("G(%s) = %s, want = %s", test.x, got, want)
+			}
+		})
+	}
+}
+
+func TestGenerator(t *testing.T) {
+	t.Parallel()
+
+	var x, y Element
+	Generator(&x, &y)
+
+	params := elliptic.P256().Params()
+	if got, want := uncompressed(&x, &y), elliptic.Marshal(elliptic.P256(), params.Gx, params.Gy); !bytes.Equal(got, want) { //nolint:staticcheck // test oracle
+		t.Errorf("Generator() = %x, want = %x", got, want)
+	}
+}
+
+func TestScalarBaseMult(t *testing.T) {
+	t.Parallel()
+
+	for range 20 {
+		k, err := ecdh.P256().GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var x, y Element
+		ScalarBaseMult(&x, &y, testScalar(k.Bytes()))
+		if got, want := uncompressed(&x, &y), k.PublicKey().Bytes(); !bytes.Equal(got, want) {
+			t.Errorf("ScalarBaseMult(%x) = %x, want = %x", k.Bytes(), got, want)
+		}
+	}
+}
+
+func TestScalarMult(t *testing.T) {
+	t.Parallel()
+
+	// k(aG) = (ka)G
+	n := elliptic.P256().Params().N
+	for range 20 {
+		a, err := ecdh.P256().GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		b, err := ecdh.P256().GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var x, y Element
+		setUncompressed(&x, &y, a.PublicKey().Bytes())
+		ScalarMult(&x, &y, &x, &y, testScalar(b.Bytes()))
+
+		ab := new(big.Int).Mul(new(big.Int).SetBytes(a.Bytes()), new(big.Int).SetBytes(b.Bytes()))
+		var wx, wy Element
+		ScalarBaseMult(&wx, &wy, testScalar(ab.Mod(ab, n).FillBytes(make([]byte, 32))))
+
+		if got, want := uncompressed(&x, &y), uncompressed(&wx, &wy); !bytes.Equal(got, want) {
+			t.Errorf("ScalarMult(%x, %x) = %x, want = %x", a.PublicKey().Bytes(), b.Bytes(), got, want)
+		}
+	}
+}
+
+func TestScalarMultEdgeCases(t *testing.T) {
+	t.Parallel()
+
+	k, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := k.PublicKey().Bytes()
+
+	var px, py, x, y Element
+	setUncompressed(&px, &py, p)
+
+	// 1P = P
+	ScalarMult(&x, &y, &px, &py, new(Scalar).One())
+	if got := uncompressed(&x, &y); !bytes.Equal(got, p) {
+		t.Errorf("ScalarMult(P, 1) = %x, want = %x", got, p)
+	}
+
+	// (n - 1)P = -P
+	ScalarMult(&x, &y, &px, &py, new(Scalar).Neg(new(Scalar).One()))
+	if x.Equal(&px) != 1 || y.Equal(new(Element).Neg(&py)) != 1 {
+		t.Errorf("ScalarMult(P, n - 1) = (%s, %s), want = (%s, -%s)", &x, &y, &px, &py)
+	}
+
+	// 0P is the point at infinity.
+	ScalarMult(&x, &y, &px, &py, new(Scalar))
+	if x.IsZero()&y.IsZero() != 1 {
+		t.Errorf("ScalarMult(P, 0) = (%s, %s), want = (0, 0)", &x, &y)
+	}
+
+	// k(identity) is the point at infinity.
+	ScalarMult(&x, &y, new(Element), new(Element), new(Scalar).One())
+	if x.IsZero()&y.IsZero() != 1 {
+		t.Errorf("ScalarMult(identity, 1) = (%s, %s), want = (0, 0)", &x, &y)
+	}
+}
+
+func TestAdd(t *testing.T) {
+	t.Parallel()
+
+	// aG + bG = (a + b)G, including when a = b.
+	n := elliptic.P256().Params().N
+	for i := range 20 {
+		a, err := ecdh.P256().GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		b := a
+		if i%2 == 1 {
+			if b, err = ecdh.P256().GenerateKey(rand.Reader); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		var ax, ay, bx, by Element
+		setUncompressed(&ax, &ay, a.PublicKey().Bytes())
+		setUncompressed(&bx, &by, b.PublicKey().Bytes())
+		Add(&ax, &ay, &ax, &ay, &bx, &by)
+
+		sum := new(big.Int).Add(new(big.Int).SetBytes(a.Bytes()), new(big.Int).SetBytes(b.Bytes()))
+		var wx, wy Element
+		ScalarBaseMult(&wx, &wy, testScalar(sum.Mod(sum, n).FillBytes(make([]byte, 32))))
+
+		if got, want := uncompressed(&ax, &ay), uncompressed(&wx, &wy); !bytes.Equal(got, want) {
+			t.Errorf("Add(%x, %x) = %x, want = %x", a.PublicKey().Bytes(), b.PublicKey().Bytes(), got, want)
+		}
+	}
+}
+
+func TestAddIdentity(t *testing.T) {
+	t.Parallel()
+
+	k, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var px, py, negY, x, y Element
+	setUncompressed(&px, &py, k.PublicKey().Bytes())
+	negY.Neg(&py)
+
+	// P + -P is the point at infinity.
+	Add(&x, &y, &px, &py, &px, &negY)
+	if x.IsZero()&y.IsZero() != 1 {
+		t.Errorf("Add(P, -P) = (%s, %s), want = (0, 0)", &x, &y)
+	}
+
+	// P + identity = P
+	Add(&x, &y, &px, &py, new(Element), new(Element))
+	if x.Equal(&px)&y.Equal(&py) != 1 {
+		t.Errorf("Add(P, identity) = (%s, %s), want = (%s, %s)", &x, &y, &px, &py)
+	}
+
+	// identity + P = P
+	Add(&x, &y, new(Element), new(Element), &px, &py)
+	if x.Equal(&px)&y.Equal(&py) != 1 {
+		t.Errorf("Add(identity, P) = (%s, %s), want = (%s, %s)", &x, &y, &px, &py)
+	}
+
+	// identity + identity = identity
+	Add(&x, &y, new(Element), new(Element), new(Element), new(Element))
+	if x.IsZero()&y.IsZero() != 1 {
+		t.Errorf("Add(identity, identity) = (%s, %s), want = (0, 0)", &x, &y)
+	}
+}
+
+func TestCompressed(t *testing.T) {
+	t.Parallel()
+
+	for range 20 {
+		k, err := ecdh.P256().GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		p := k.PublicKey().Bytes()
+
+		var px, py Element
+		setUncompressed(&px, &py, p)
+
+		b := AppendCompressed(nil, &px, &py)
+		if want := elliptic.MarshalCompressed(elliptic.P256(), new(big.Int).SetBytes(p[1:33]), new(big.Int).SetBytes(p[33:])); !bytes.Equal(b, want) {
+			t.Fatalf("AppendCompressed(%x) = %x, want = %x", p, b, want)
+		}
+
+		var x, y Element
+		if ok := Decompress(&x, &y, (*[33]byte)(b)); ok != 1 {
+			t.Fatalf("Decompress(%x) ok = %d, want = 1", b, ok)
+		}
+
+		if got := uncompressed(&x, &y); !bytes.Equal(got, p) {
+			t.Errorf("Decompress(%x) = %x, want = %x", b, got, p)
+		}
+	}
+}
+
+func TestDecompressInvalid(t *testing.T) {
+	t.Parallel()
+
+	var x, y Element
+	Generator(&x, &y)
+	g := AppendCompressed(nil, &x, &y)
+
+	var tests = []struct {
+		name string
+		b    []byte
+	}{
+		{"identity", make([]byte, 33)},
+		{"uncompressed tag", append([]byte{4}, g[1:]...)},
+		{"not on curve", append(append([]byte{2}, make([]byte, 31)...), 1)},
+		{"non-canonical x", append([]byte{2}, bytes.Repeat([]byte{0xff}, 32)...)},
+	}
+	for _, test := range tests {
+		if ok := Decompress(&x, &y, (*[33]byte)(test.b)); ok != 0 {
+			t.Errorf("Decompress(%s) ok = %d, want = 0", test.name, ok)
+		}
+	}
+}
+
+// testScalar returns k, a 32-byte big-endian integer less than n, as a Scalar.
+func testScalar(k []byte) *Scalar {
+	var s Scalar
+	if s.SetCanonicalBytes((*[32]byte)(k)) != 1 {
+		panic("non-canonical scalar")
+	}
+	return &s
+}
+
+// setUncompressed sets (x, y) to the point with the 65-byte uncompressed SEC encoding b.
+func setUncompressed(x, y *Element, b []byte) {
+	x.SetBytes((*[32]byte)(b[1:33]))
+	y.SetBytes((*[32]byte)(b[33:]))
+}
+
+// uncompressed returns the 65-byte uncompressed SEC encoding of (x, y).
+func uncompressed(x, y *Element) []byte {
+	return append(append([]byte{4}, x.Bytes()...), y.Bytes()...)
+}
@@ -0,0 +1,158 @@
+package p256
+
+import (
+	"encoding/hex"
+	"math/bits"
+)
+
+// Scalar is an integer modulo the order of P-256,
+// n = 2^256 - 2^224 + 2^192 - 0x4319055258e8617b0c46353d039cdaaf, backed by a fiat-crypto
+// Montgomery representation. All operations are constant time unless documented otherwise.
+//
+// The zero value is a valid zero scalar.
+type Scalar struct {
+	x fiatP256ScalarMontgomeryDomainFieldElement
+}
+
+// p256N is the group order in little-endian 64-bit limbs.
+const (
+	p256N0 = 0xf3b9cac2fc632551
+	p256N1 = 0xbce6faada7179e84
+	p256N2 = 0xffffffffffffffff
+	p256N3 = 0xffffffff00000000
+)
+
+// One sets s to 1.
+func (s *Scalar) One() *Scalar {
+	fiatP256ScalarSetOne(&s.x)
+	return s
+}
+
+// Set sets s to x.
+func (s *Scalar) Set(x *Scalar) *Scalar {
+	s.x = x.x
+	return s
+}
+
+// Bytes returns the 32-byte big-endian encoding of s.
+func (s *Scalar) Bytes() []byte {
+	var out [32]byte
+	return s.FillBytes(&out)
+}
+
+// FillBytes sets out to the 32-byte big-endian encoding of s, and returns it as a slice.
+func (s *Scalar) FillBytes(out *[32]byte) []byte {
+	var t fiatP256ScalarNonMontgomeryDomainFieldElement
+	fiatP256ScalarFromMontgomery(&t, &s.x)
+	fiatP256ScalarToBytes(out, (*[4]uint64)(&t))
+	invertEndianness(out[:])
+	return out[:]
+}
+
+// SetBytes sets s to the 32-byte big-endian value b, reduced mod n.
+func (s *Scalar) SetBytes(b *[32]byte) *Scalar {
+	s.SetCanonicalBytes(b)
+	return s
+}
+
+// SetCanonicalBytes sets s to the 32-byte big-endian value b, reduced mod n, and returns 1 if b was
+// less than n and 0 otherwise.
+func (s *Scalar) SetCanonicalBytes(b *[32]byte) int {
+	in := *b
+	invertEndianness(in[:])
+
+	var t fiatP256ScalarNonMontgomeryDomainFieldElement
+	fiatP256ScalarFromBytes((*[4]uint64)(&t), &in)
+
+	// Any 256-bit value is less than 2n, so a single conditional subtraction fully reduces it.
+	var r [4]uint64
+	var borrow uint64
+	r[0], borrow = bits.Sub64(t[0], p256N0, 0)
+	r[1], borrow = bits.Sub64(t[1], p256N1, borrow)
+	r[2], borrow = bits.Sub64(t[2], p256N2, borrow)
+	r[3], borrow = bits.Sub64(t[3], p256N3, borrow)
+	fiatP256ScalarSelectznz((*[4]uint64)(&t), fiatP256ScalarUint1(borrow), &r, (*[4]uint64)(&t))
+
+	fiatP256ScalarToMontgomery(&s.x, &t)
+	return int(borrow)
+}
+
+// SetWideBytes sets s to the 48-byte big-endian value b reduced mod n, as hash_to_field does in RFC
+// 9380 with L = 48.
+func (s *Scalar) SetWideBytes(b *[48]byte) *Scalar {
+	var hi, lo [32]byte
+	copy(hi[16:], b[:16])
+	copy(lo[:], b[16:])
+
+	// s = hi * 2^256 + lo
+	var r Scalar
+	r.SetBytes(&[32]byte{
+		0x00, 0x00, 0x00, 0x00, 0xff, 0xff, 0xff, 0xff, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x43, 0x19, 0x05, 0x52, 0x58, 0xe8, 0x61, 0x7b, 0x0c, 0x46, 0x35, 0x3d, 0x03, 0x9c, 0xda, 0xaf,
+	})
+	s.SetBytes(&hi)
+	s.Mul(s, &r)
+	return s.Add(s, r.SetBytes(&lo))
+}
+
+// String returns the hex encoding of s.
+func (s *Scalar) String() string {
+	return hex.EncodeToString(s.Bytes())
+}
+
+// Add sets s to x + y.
+func (s *Scalar) Add(x, y *Scalar) *Scalar {
+	fiatP256ScalarAdd(&s.x, &x.x, &y.x)
+	return s
+}
+
+// Sub sets s to x - y.
+func (s *Scalar) Sub(x, y *Scalar) *Scalar {
+	fiatP256ScalarSub(&s.x, &x.x, &y.x)
+	return s
+}
+
+// Mul sets s to x * y.
+func (s *Scalar) Mul(x, y *Scalar) *Scalar {
+	fiatP256ScalarMul(&s.x, &x.x, &y.x)
+	return s
+}
+
+// Neg sets s to -x.
+func (s *Scalar) Neg(x *Scalar) *Scalar {
+	fiatP256ScalarOpp(&s.x, &x.x)
+	return s
+}
+
+// Invert sets s to 1/x. If x == 0, Invert sets s to 0.
+func (s *Scalar) Invert(x *Scalar) *Scalar {
+	// Inversion is implemented as exponentiation by n - 2, which is public, so branching on its
+	// bits does not leak anything about x.
+	exp := [4]uint64{p256N0 - 2, p256N1, p256N2, p256N3}
+
+	var z Scalar
+	z.One()
+	for i := 255; i >= 0; i-- {
+		fiatP256ScalarSquare(&z.x, &z.x)
+		if exp[i/64]>>(i%64)&1 == 1 {
+			z.Mul(&z, x)
+		}
+	}
+	return s.Set(&z)
+}
+
+// Equal returns 1 if s == x, and 0 otherwise.
+func (s *Scalar) Equal(x *Scalar) int {
+	var d uint64
+	for i := range s.x {
+		d |= s.x[i] ^ x.x[i]
+	}
+	return isZeroWord(d)
+}
+
+// IsZero returns 1 if s == 0, and 0 otherwise.
+func (s *Scalar) IsZero() int {
+	var d uint64
+	fiatP256ScalarNonzero(&d, (*[4]uint64)(&s.x))
+	return isZeroWord(d)
+}
@@ -0,0 +1,116 @@
+package p256
+
+import (
+	"bytes"
+	"crypto/elliptic"
+	"crypto/rand"
+	"math/big"
+	"testing"
+)
+
+func TestScalarArithmetic(t *testing.T) {
+	t.Parallel()
+
+	n := elliptic.P256().Params().N
+	for range 1000 {
+		a, x := randomScalar(t)
+		b, y := randomScalar(t)
+
+		for _, tc := range []struct {
+			name string
+			got  *Scalar
+			want *big.Int
+		}{
+			{"add", new(Scalar).Add(&a, &b), new(big.Int).Add(x, y)},
+			{"sub", new(Scalar).Sub(&a, &b), new(big.Int).Sub(x, y)},
+			{"neg", new(Scalar).Neg(&a), new(big.Int).Neg(x)},
+			{"mul", new(Scalar).Mul(&a, &b), new(big.Int).Mul(x, y)},
+			{"invert", new(Scalar).Invert(&a), new(big.Int).ModInverse(x, n)},
+		} {
+			if got, want := tc.got.Bytes(), tc.want.Mod(tc.want, n).FillBytes(make([]byte, 32)); !bytes.Equal(got, want) {
+				t.Errorf("%s(%x, %x) = %x, want = %x", tc.name, x, y, got, want)
+			}
+		}
+	}
+}
+
+func TestScalarInvertZero(t *testing.T) {
+	t.Parallel()
+
+	if got := new(Scalar).Invert(new(Scalar)); got.IsZero() != 1 {
+		t.Errorf("Invert(0) = %s, want = 0", got)
+	}
+}
+
+func TestScalarSetWideBytes(t *testing.T) {
+	t.Parallel()
+
+	n := elliptic.P256().Params().N
+	for _, b := range [][48]byte{{}, bytes48(0xff), bytes48(0x80), randomBytes48(t), randomBytes48(t)} {
+		want := new(big.Int).SetBytes(b[:])
+		want.Mod(want, n)
+
+		if got := new(Scalar).SetWideBytes(&b).Bytes(); !bytes.Equal(got, want.FillBytes(make([]byte, 32))) {
+			t.Errorf("SetWideBytes(%x) = %x, want = %x", b, got, want)
+		}
+	}
+}
+
+func TestScalarSetCanonicalBytes(t *testing.T) {
+	t.Parallel()
+
+	n := elliptic.P256().Params().N
+	for _, tc := range []struct {
+		name string
+		b    *big.Int
+		ok   int
+	}{
+		{"zero", big.NewInt(0), 1},
+		{"one", big.NewInt(1), 1},
+		{"n-1", new(big.Int).Sub(n, big.NewInt(1)), 1},
+		{"n", n, 0},
+		{"max", new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1)), 0},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			b := [32]byte(tc.b.FillBytes(make([]byte, 32)))
+			var s Scalar
+			if got := s.SetCanonicalBytes(&b); got != tc.ok {
+				t.Fatalf("SetCanonicalBytes(%x) = %d, want = %d", b, got, tc.ok)
+			}
+
+			want := new(big.Int).Mod(tc.b, n).FillBytes(make([]byte, 32))
+			if !bytes.Equal(s.Bytes(), want) {
+				t.Errorf("Bytes() = %x, want = %x", s.Bytes(), want)
+			}
+		})
+	}
+}
+
+func randomScalar(t *testing.T) (Scalar, *big.Int) {
+	t.Helper()
+
+	var b [48]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		t.Fatal(err)
+	}
+
+	var s Scalar
+	s.SetWideBytes(&b)
+	return s, new(big.Int).SetBytes(s.Bytes())
+}
+
+func randomBytes48(t *testing.T) [48]byte {
+	t.Helper()
+
+	var b [48]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func bytes48(v byte) [48]byte {
+	return [48]byte(bytes.Repeat([]byte{v}, 48))
+}
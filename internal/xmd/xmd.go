@@ -0,0 +1,92 @@
+// Package xmd implements expand_message_xmd from RFC 9380, Section 5.3.1, with SHA-256.
+package xmd
+
+import (
+	"crypto/sha256"
+	"errors"
+	"math"
+)
+
+var (
+	// ErrInvalidDST is returned when a domain separation tag is empty.
+	ErrInvalidDST = errors.New("xmd: invalid domain separation tag")
+
+	// ErrInvalidLength is returned when the requested output length is not in [1, MaxLength].
+	ErrInvalidLength = errors.New("xmd: invalid output length")
+)
+
+// MaxLength is the longest output expand_message_xmd can produce with SHA-256.
+const MaxLength = math.MaxUint8 * sha256.Size
+
+// Expand returns lenInBytes bytes derived from msg and dst using expand_message_xmd with SHA-256.
+// Tags longer than 255 bytes are hashed as described in RFC 9380, Section 5.3.3. It returns
+// ErrInvalidDST if dst is empty, and ErrInvalidLength if lenInBytes is not in [1, MaxLength].
+func Expand(msg, dst []byte, lenInBytes int) ([]byte, error) {
+	if len(dst) == 0 {
+		return nil, ErrInvalidDST
+	}
+
+	if lenInBytes < 1 || lenInBytes > MaxLength {
+		return nil, ErrInvalidLength
+	}
+
+	out := make([]byte, lenInBytes)
+	expand(out, msg, dst)
+	return out, nil
+}
+
+// Expand48 returns 48 bytes derived from msg and dst using expand_message_xmd with SHA-256, as
+// hash_to_field does for a single element with L = 48. dst must not be empty.
+func Expand48(msg, dst []byte) [48]byte {
+	var out [48]byte
+	expand(out[:], msg, dst)
+	return out
+}
+
+// expand fills out with bytes derived from msg and dst using expand_message_xmd with SHA-256. dst
+// must not be empty, and len(out) must be in [1, MaxLength].
+func expand(out, msg, dst []byte) {
+	const bInBytes, sInBytes = sha256.Size, sha256.BlockSize
+
+	// Hash oversized tags, as in Section 5.3.3.
+	if len(dst) > math.MaxUint8 {
+		h := sha256.New()
+		_, _ = h.Write([]byte("H2C-OVERSIZE-DST-"))
+		_, _ = h.Write(dst)
+		dst = h.Sum(nil)
+	}
+
+	lenInBytes := len(out)
+	ell := (lenInBytes + bInBytes - 1) / bInBytes
+	dstPrime := append(dst[:len(dst):len(dst)], byte(len(dst)))
+
+	// b_0 = H(Z_pad || msg || l_i_b_str || I2OSP(0, 1) || DST_prime)
+	h := sha256.New()
+	_, _ = h.Write(make([]byte, sInBytes))
+	_, _ = h.Write(msg)
+	_, _ = h.Write([]byte{byte(lenInBytes >> 8), byte(lenInBytes), 0})
+	_, _ = h.Write(dstPrime)
+	b0 := h.Sum(nil)
+
+	// b_1 = H(b_0 || I2OSP(1, 1) || DST_prime)
+	h.Reset()
+	_, _ = h.Write(b0)
+	_, _ = h.Write([]byte{1})
+	_, _ = h.Write(dstPrime)
+	bi := h.Sum(nil)
+	n := copy(out, bi)
+
+	// b_i = H(strxor(b_0, b_(i - 1)) || I2OSP(i, 1) || DST_prime)
+	for i := 2; i <= ell; i++ {
+		for j := range bi {
+			bi[j] ^= b0[j]
+		}
+
+		h.Reset()
+		_, _ = h.Write(bi)
+		_, _ = h.Write([]byte{byte(i)})
+		_, _ = h.Write(dstPrime)
+		bi = h.Sum(bi[:0])
+		n += copy(out[n:], bi)
+	}
+}
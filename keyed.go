@@ -0,0 +1,83 @@
+package elligator
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+	"io"
+)
+
+// MinKeySize is the minimum length of a key for EncodeKeyed and DecodeKeyed.
+const MinKeySize = 32
+
+// ErrInvalidKey is returned when a key for EncodeKeyed or DecodeKeyed is shorter than MinKeySize.
+var ErrInvalidKey = errors.New("elligator: invalid key")
+
+// EncodeKeyed maps the given SEC-encoded point to a random 64-byte bitstring, then permutes it
+// under the given key. The result is indistinguishable from random even to a party who knows the
+// scheme, and can only be mapped back to the point by DecodeKeyed with the same key.
+//
+// The permutation is a four-round Feistel network over the two 32-byte halves of the encoding,
+// with HMAC-SHA-256 as the round function:
+//
+//	F_i(x) = HMAC-SHA-256(key, "elligator-squared-p256 keyed v1" || i || x)
+//
+// Even rounds XOR F_i of the second half into the first half; odd rounds XOR F_i of the first half
+// into the second half.
+//
+// The key should be uniformly random. EncodeKeyed returns ErrInvalidKey if it is shorter than
+// MinKeySize.
+func EncodeKeyed(p, key []byte, rand io.Reader) ([]byte, error) {
+	if len(key) < MinKeySize {
+		return nil, ErrInvalidKey
+	}
+
+	b, err := Encode(p, rand)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range keyedRounds {
+		keyedRound(b, key, byte(i))
+	}
+	return b, nil
+}
+
+// DecodeKeyed inverts the permutation applied by EncodeKeyed and maps the result to an
+// uncompressed SEC-encoded point. Decoding with the wrong key does not return an error; it
+// returns a pseudorandom point, so the keyed mode itself reveals nothing to a party without the
+// key. It returns ErrInvalidKey if the key is shorter than MinKeySize.
+func DecodeKeyed(b, key []byte) ([]byte, error) {
+	if len(key) < MinKeySize {
+		return nil, ErrInvalidKey
+	}
+
+	if len(b) != 64 {
+		return nil, ErrInvalidEncoding
+	}
+
+	var buf [64]byte
+	copy(buf[:], b)
+	for i := keyedRounds - 1; i >= 0; i-- {
+		keyedRound(buf[:], key, byte(i))
+	}
+	return Decode(buf[:])
+}
+
+// keyedRounds is the number of Feistel rounds in the keyed permutation.
+const keyedRounds = 4
+
+// keyedRound applies the i-th Feistel round to b in place. Each round is its own inverse.
+func keyedRound(b, key []byte, i byte) {
+	src, dst := b[32:], b[:32]
+	if i&1 == 1 {
+		src, dst = dst, src
+	}
+
+	h := hmac.New(sha256.New, key)
+	_, _ = h.Write([]byte("elligator-squared-p256 keyed v1"))
+	_, _ = h.Write([]byte{i})
+	_, _ = h.Write(src)
+	subtle.XORBytes(dst, dst, h.Sum(nil))
+}
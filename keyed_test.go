@@ -0,0 +1,108 @@
+package elligator
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"testing"
+)
+
+func TestEncodeKeyed(t *testing.T) {
+	t.Parallel()
+
+	p, _ := hex.DecodeString("046b17d1f2e12c4247f8bce6e563a440f277037d812deb33a0f4a13945d898c2964fe342e2fe1a7f9b8ee7eb4a7c0f9e162bce33576b315ececbb6406837bf51f5")
+	key := testKey(0)
+
+	for range 10 {
+		b, err := EncodeKeyed(p, key, rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := DecodeKeyed(b, key)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(got, p) {
+			t.Errorf("DecodeKeyed(%x) = %x, want = %x", b, got, p)
+		}
+
+		unkeyed, err := Decode(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if bytes.Equal(unkeyed, p) {
+			t.Errorf("Decode(%x) = %x, want a different point", b, unkeyed)
+		}
+
+		wrong, err := DecodeKeyed(b, testKey(1))
+		if err != nil {
+			t.Fatalf("DecodeKeyed with the wrong key returned %v, want a pseudorandom point", err)
+		}
+
+		if bytes.Equal(wrong, p) {
+			t.Errorf("DecodeKeyed(%x) with the wrong key = %x, want a different point", b, wrong)
+		}
+	}
+}
+
+func TestDecodeKeyedInvalidLength(t *testing.T) {
+	t.Parallel()
+
+	if _, err := DecodeKeyed(make([]byte, 63), testKey(0)); !errors.Is(err, ErrInvalidEncoding) {
+		t.Errorf("DecodeKeyed(short) = %v, want = %v", err, ErrInvalidEncoding)
+	}
+}
+
+func TestKeyedInvalidKey(t *testing.T) {
+	t.Parallel()
+
+	p, _ := hex.DecodeString("046b17d1f2e12c4247f8bce6e563a440f277037d812deb33a0f4a13945d898c2964fe342e2fe1a7f9b8ee7eb4a7c0f9e162bce33576b315ececbb6406837bf51f5")
+	for _, key := range [][]byte{nil, {}, []byte("key"), testKey(0)[:MinKeySize-1]} {
+		if _, err := EncodeKeyed(p, key, rand.Reader); !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("EncodeKeyed(key=%x) = %v, want = %v", key, err, ErrInvalidKey)
+		}
+
+		if _, err := DecodeKeyed(make([]byte, 64), key); !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("DecodeKeyed(key=%x) = %v, want = %v", key, err, ErrInvalidKey)
+		}
+	}
+}
+
+func TestKeyedPermutation(t *testing.T) {
+	t.Parallel()
+
+	b := make([]byte, 64)
+	for i := range b {
+		b[i] = byte(i)
+	}
+	in := bytes.Clone(b)
+	key := make([]byte, MinKeySize)
+	for i := range key {
+		key[i] = byte(0x20 + i)
+	}
+
+	for i := range keyedRounds {
+		keyedRound(b, key, byte(i))
+	}
+
+	if got, want := hex.EncodeToString(b), "b171d051a851554a9011331014ae4655040dee969458702e60be18a6194cb8c139b68e40bacc6f076df303ac25203f202d4ecaf684067630a2e903207eab2ed4"; got != want {
+		t.Errorf("permute(%x) = %s, want = %s", in, got, want)
+	}
+
+	for i := keyedRounds - 1; i >= 0; i-- {
+		keyedRound(b, key, byte(i))
+	}
+
+	if !bytes.Equal(b, in) {
+		t.Errorf("inverse permutation = %x, want = %x", b, in)
+	}
+}
+
+// testKey returns a distinct MinKeySize-byte key for each i.
+func testKey(i byte) []byte {
+	return bytes.Repeat([]byte{i}, MinKeySize)
+}
@@ -0,0 +1,101 @@
+package elligator
+
+import "github.com/codahale/elligator-squared-p256/internal/p256"
+
+// MapToCurve maps the field element u, a 32-byte big-endian integer reduced mod p, to a point on
+// P-256 and returns its uncompressed SEC encoding. This is the function f used by Elligator
+// Squared, a variant of the Shallue–van de Woestijne map, which is defined in three cases:
+//
+//  1. If u ∈ {-1, 0, 1}, f(u) is the point at infinity, which is returned as the single byte 0x00.
+//  2. Otherwise, let X_0(u) = -B/A (1 + 1/(u^4 - u^2)). If g(X_0(u)) = X_0(u)^3 + A X_0(u) + B is
+//     a square, f(u) = (X_0(u), √g(X_0(u))).
+//  3. Otherwise, let X_1(u) = -u^2 X_0(u), for which g(X_1(u)) is always a square, and
+//     f(u) = (X_1(u), -√g(X_1(u))).
+//
+// In case 2 the y-coordinate is itself a square, and in case 3 it is not, which is how MapToField
+// tells the cases apart. MapToCurve runs in constant time.
+//
+// The output of MapToCurve is not uniformly distributed over the curve, and some points have no
+// preimage at all, so it is not suitable on its own as a hash to the curve.
+func MapToCurve(u [32]byte) []byte {
+	var e, x, y fieldElement
+	e.SetBytes(&u)
+	f(&x, &y, &e)
+	if x.IsZero()&y.IsZero() == 1 {
+		return []byte{0}
+	}
+	return appendUncompressed(nil, &x, &y)
+}
+
+// MapToField returns the jth of the up to four preimages of the given SEC-encoded point under
+// MapToCurve, and whether that preimage exists. It is the function r used by Elligator Squared.
+//
+// Let ω = (A/B) x + 1. The preimages are the values of
+//
+//	u = ±√((ω ± √(ω^2 - 4ω)) / d)
+//
+// where d = 2ω if y is a square, i.e. the point came from case 2 of MapToCurve, and d = 2
+// otherwise. Bit 1 of j selects the sign of the inner square root, and bit 0 selects the sign of
+// the outer square root. The jth preimage exists if both square roots exist.
+//
+// MapToField returns false if the point is malformed, is the point at infinity, or j is not in [0,
+// 4). Otherwise, it runs in constant time.
+func MapToField(point []byte, j int) ([32]byte, bool) {
+	var u [32]byte
+	if j < 0 || j > 3 {
+		return u, false
+	}
+
+	var x, y, e fieldElement
+	if err := p256SetBytes(&x, &y, point, AllFormats); err != nil {
+		return u, false
+	}
+
+	ok := r(&e, &x, &y, byte(j))
+	e.FillBytes(&u)
+	return u, ok == 1
+}
+
+// Preimages returns every v such that MapToCurve(u) + MapToCurve(v) equals the given SEC-encoded
+// point, which are the second halves of the representatives of the point with first half u. It
+// returns nil if the point is malformed or the point at infinity.
+//
+// Each v is one of the preimages of Q = P - f(u) returned by MapToField. Which of the X_0 and X_1
+// branches of f they come from is determined by Q, so there are at most four. If f(u) = P, then Q is
+// the point at infinity and its preimages are -1, 0, and 1.
+//
+// Preimages runs in variable time.
+func Preimages(point []byte, u [32]byte) [][32]byte {
+	var px, py fieldElement
+	if err := p256SetBytes(&px, &py, point, AllFormats); err != nil {
+		return nil
+	}
+
+	var e, qx, qy fieldElement
+	e.SetBytes(&u)
+	f(&qx, &qy, &e)
+	qy.Neg(&qy)
+	p256.Add(&qx, &qy, &px, &py, &qx, &qy)
+
+	var vs [][32]byte
+	if qx.IsZero()&qy.IsZero() == 1 {
+		var one, minusOne fieldElement
+		one.One()
+		minusOne.Neg(&one)
+		for _, v := range []*fieldElement{&minusOne, new(fieldElement), &one} {
+			vs = append(vs, [32]byte(v.Bytes()))
+		}
+		return vs
+	}
+
+	var es [4]fieldElement
+	for _, v := range es[:preimages(&es, &qx, &qy)] {
+		vs = append(vs, [32]byte(v.Bytes()))
+	}
+	return vs
+}
+
+// PreimageCount returns the number of preimages Preimages would return.
+func PreimageCount(point []byte, u [32]byte) int {
+	return len(Preimages(point, u))
+}
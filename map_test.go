@@ -0,0 +1,254 @@
+package elligator
+
+import (
+	"bytes"
+	"crypto/ecdh"
+	"crypto/rand"
+	"encoding/hex"
+	"math"
+	"slices"
+	"testing"
+	"testing/quick"
+)
+
+func TestMapToCurve(t *testing.T) {
+	t.Parallel()
+
+	// MapToCurve agrees with f.
+	check := func(u [32]byte) bool {
+		var e, x, y fieldElement
+		e.SetBytes(&u)
+		f(&x, &y, &e)
+
+		p := MapToCurve(u)
+		if _, err := ecdh.P256().NewPublicKey(p); err != nil {
+			return false
+		}
+		return bytes.Equal(p, appendUncompressed(nil, &x, &y))
+	}
+	if err := quick.Check(check, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMapToCurveExceptional(t *testing.T) {
+	t.Parallel()
+
+	for _, s := range []string{
+		"0000000000000000000000000000000000000000000000000000000000000000", // 0
+		"0000000000000000000000000000000000000000000000000000000000000001", // 1
+		"ffffffff00000001000000000000000000000000fffffffffffffffffffffffe", // -1
+		"ffffffff00000001000000000000000000000000ffffffffffffffffffffffff", // p, which reduces to 0
+	} {
+		b, err := hex.DecodeString(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		u := [32]byte(b)
+		if got, want := MapToCurve(u), []byte{0}; !bytes.Equal(got, want) {
+			t.Errorf("MapToCurve(%s) = %x, want = %x", s, got, want)
+		}
+	}
+}
+
+func TestMapToField(t *testing.T) {
+	t.Parallel()
+
+	// MapToCurve(MapToField(P, j)) == P whenever the jth root exists.
+	roots := 0
+	for range 200 {
+		k, err := ecdh.P256().GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		p := k.PublicKey().Bytes()
+
+		for j := range 4 {
+			u, ok := MapToField(p, j)
+			if !ok {
+				continue
+			}
+			roots++
+
+			if got := MapToCurve(u); !bytes.Equal(got, p) {
+				t.Errorf("MapToCurve(MapToField(%x, %d)) = %x", p, j, got)
+			}
+		}
+	}
+
+	// Each root exists for roughly a quarter of points, so 200 points have roughly 200 roots.
+	if roots < 100 || roots > 300 {
+		t.Errorf("found %d roots for 200 points, want roughly 200", roots)
+	}
+}
+
+func TestMapToFieldRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	// Every point in the image of MapToCurve has its preimage among the roots.
+	check := func(u [32]byte) bool {
+		var e fieldElement
+		e.SetBytes(&u)
+		e.FillBytes(&u)
+
+		p := MapToCurve(u)
+		if len(p) == 1 {
+			return true
+		}
+
+		for j := range 4 {
+			if v, ok := MapToField(p, j); ok && v == u {
+				return true
+			}
+		}
+		return false
+	}
+	if err := quick.Check(check, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMapToFieldInvalid(t *testing.T) {
+	t.Parallel()
+
+	k, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = []struct {
+		name  string
+		point []byte
+		j     int
+	}{
+		{name: "identity", point: []byte{0}, j: 0},
+		{name: "malformed", point: []byte{4, 1, 2, 3}, j: 0},
+		{name: "negative j", point: k.PublicKey().Bytes(), j: -1},
+		{name: "large j", point: k.PublicKey().Bytes(), j: 4},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			if u, ok := MapToField(test.point, test.j); ok {
+				t.Errorf("MapToField(%x, %d) = %x, true, want false", test.point, test.j, u)
+			}
+		})
+	}
+}
+
+func TestPreimages(t *testing.T) {
+	t.Parallel()
+
+	k, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := k.PublicKey().Bytes()
+
+	for range 100 {
+		var u [32]byte
+		if _, err := rand.Read(u[:]); err != nil {
+			t.Fatal(err)
+		}
+
+		vs := Preimages(p, u)
+		if got, want := PreimageCount(p, u), len(vs); got != want {
+			t.Errorf("PreimageCount() = %d, want = %d", got, want)
+		}
+
+		if len(vs) > 4 {
+			t.Errorf("Preimages(%x, %x) has %d elements, want at most 4", p, u, len(vs))
+		}
+
+		for _, v := range vs {
+			q, err := Decode(append(u[:], v[:]...))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !bytes.Equal(q, p) {
+				t.Errorf("Decode(%x || %x) = %x, want = %x", u, v, q, p)
+			}
+		}
+	}
+}
+
+func TestPreimagesExceptional(t *testing.T) {
+	t.Parallel()
+
+	// If f(u) = P, then f(v) must be the point at infinity.
+	var u [32]byte
+	u[31] = 2
+	p := MapToCurve(u)
+
+	var want [][32]byte
+	for _, s := range []string{
+		"ffffffff00000001000000000000000000000000fffffffffffffffffffffffe",
+		"0000000000000000000000000000000000000000000000000000000000000000",
+		"0000000000000000000000000000000000000000000000000000000000000001",
+	} {
+		b, err := hex.DecodeString(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want = append(want, [32]byte(b))
+	}
+
+	if got := Preimages(p, u); !slices.Equal(got, want) {
+		t.Errorf("Preimages(%x, %x) = %x, want = %x", p, u, got, want)
+	}
+
+	if got := Preimages([]byte{0}, u); got != nil {
+		t.Errorf("Preimages(identity) = %x, want = nil", got)
+	}
+}
+
+func TestEncodeAcceptanceProbability(t *testing.T) {
+	t.Parallel()
+
+	k, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := k.PublicKey().Bytes()
+
+	var px, py fieldElement
+	if err := p256SetBytes(&px, &py, p, AllFormats); err != nil {
+		t.Fatal(err)
+	}
+
+	// Encode picks u and j uniformly at random and accepts the candidate if the jth root exists,
+	// so it accepts each u with probability PreimageCount(P, u)/4, weighting each representative
+	// (u, v) equally. Check that the candidates Encode accepts are exactly the preimages.
+	const samples = 1_000
+	accepted, preimages := 0, 0
+	for range samples {
+		var u [32]byte
+		if _, err := rand.Read(u[:]); err != nil {
+			t.Fatal(err)
+		}
+
+		var e, v fieldElement
+		e.SetBytes(&u)
+		var vs [][32]byte
+		for j := range byte(4) {
+			if candidate(&v, &px, &py, &e, j) == 1 {
+				accepted++
+				vs = append(vs, [32]byte(v.Bytes()))
+			}
+		}
+
+		want := Preimages(p, u)
+		preimages += len(want)
+		if !slices.Equal(vs, want) {
+			t.Errorf("candidates for u = %x: %x, want = %x", u, vs, want)
+		}
+	}
+
+	// Each point has on average one preimage per u, so Encode accepts a quarter of candidates.
+	rate := float64(accepted) / (4 * samples)
+	if math.Abs(rate-0.25) > 0.05 {
+		t.Errorf("acceptance rate = %f (%d preimages), want = 0.25", rate, preimages)
+	}
+}
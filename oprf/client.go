@@ -0,0 +1,165 @@
+package oprf
+
+import (
+	"io"
+	"math"
+)
+
+// Client blinds inputs and finalizes the Server's evaluations of them into PRF outputs.
+type Client struct {
+	mode Mode
+	pub  *PublicKey
+	config
+}
+
+// NewClient returns a Client for the given mode. The Server's public key is required in ModeVOPRF
+// and ModePOPRF, for which NewClient returns ErrInvalidKey if it is nil, and ignored in ModeOPRF.
+func NewClient(mode Mode, pub *PublicKey, opts ...Option) (*Client, error) {
+	if err := checkMode(mode, nil); err != nil {
+		return nil, err
+	}
+
+	if mode != ModeOPRF && pub == nil {
+		return nil, ErrInvalidKey
+	}
+	return &Client{mode: mode, pub: pub, config: newConfig(opts)}, nil
+}
+
+// FinalizeData is the state a Client keeps between blinding inputs and finalizing their
+// evaluations. It must not be reused.
+type FinalizeData struct {
+	inputs  [][]byte
+	blinds  []scalar
+	blinded [][]byte
+	info    []byte
+	tweaked []byte
+}
+
+// Blind blinds the given inputs, reading a random 32-byte blind for each from rand, and returns
+// the state needed to finalize them along with the blinded elements to send to the Server. Info is
+// the public POPRF info, and must be nil in the other modes.
+//
+// Blind returns ErrInvalidBatch if there are no inputs, ErrInvalidInput if an input or the info is
+// longer than 65535 bytes or an input hashes to the point at infinity, ErrInvalidInfo if info is
+// given outside of ModePOPRF, and ErrRandom if rand yields no valid blind after many attempts.
+func (c *Client) Blind(rand io.Reader, inputs [][]byte, info []byte) (*FinalizeData, [][]byte, error) {
+	if err := checkMode(c.mode, info); err != nil {
+		return nil, nil, err
+	}
+
+	if len(inputs) == 0 {
+		return nil, nil, ErrInvalidBatch
+	}
+
+	d := &FinalizeData{
+		inputs:  make([][]byte, len(inputs)),
+		blinds:  make([]scalar, len(inputs)),
+		blinded: make([][]byte, len(inputs)),
+		info:    append([]byte(nil), info...),
+	}
+
+	if c.mode == ModePOPRF {
+		tweaked, err := tweakedKey(c.pub.b, info)
+		if err != nil {
+			return nil, nil, err
+		}
+		d.tweaked = tweaked
+	}
+
+	for i, input := range inputs {
+		if len(input) > math.MaxUint16 {
+			return nil, nil, ErrInvalidInput
+		}
+
+		p, err := hashToGroup(c.mode, input)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if err := randomScalar(&d.blinds[i], rand); err != nil {
+			return nil, nil, err
+		}
+
+		if d.blinded[i], err = scalarMult(p, &d.blinds[i]); err != nil {
+			return nil, nil, err
+		}
+		d.inputs[i] = append([]byte(nil), input...)
+	}
+
+	out := make([][]byte, len(inputs))
+	for i, p := range d.blinded {
+		var err error
+		if out[i], err = c.serialize(rand, p); err != nil {
+			return nil, nil, err
+		}
+	}
+	return d, out, nil
+}
+
+// Finalize verifies the Server's proof, if the mode has one, and unblinds the evaluated elements
+// into 32-byte PRF outputs, one for each input passed to Blind. The proof is ignored in ModeOPRF.
+//
+// Finalize returns ErrInvalidBatch if the number of evaluated elements does not match the number of
+// inputs, ErrInvalidElement if an evaluated element is malformed, and ErrVerify if the proof does
+// not verify.
+func (c *Client) Finalize(d *FinalizeData, evaluated [][]byte, proof []byte) ([][]byte, error) {
+	if len(evaluated) != len(d.inputs) {
+		return nil, ErrInvalidBatch
+	}
+
+	elems := make([][]byte, len(evaluated))
+	for i, b := range evaluated {
+		var err error
+		if elems[i], err = c.deserialize(b); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := c.verify(d, elems, proof); err != nil {
+		return nil, err
+	}
+
+	outputs := make([][]byte, len(elems))
+	for i, e := range elems {
+		unblinded, err := scalarMult(e, new(scalar).Invert(&d.blinds[i]))
+		if err != nil {
+			return nil, err
+		}
+		outputs[i] = finalize(c.mode, d.inputs[i], d.info, unblinded)
+	}
+	return outputs, nil
+}
+
+// verify verifies the proof for the mode, or does nothing in ModeOPRF.
+func (c *Client) verify(d *FinalizeData, evaluated [][]byte, proof []byte) error {
+	switch c.mode {
+	case ModeOPRF:
+		return nil
+	case ModeVOPRF:
+		return verifyProof(c.mode, generator(), c.pub.b, d.blinded, evaluated, proof)
+	case ModePOPRF:
+		return verifyProof(c.mode, generator(), d.tweaked, evaluated, d.blinded, proof)
+	default:
+		return ErrInvalidMode
+	}
+}
+
+// tweakedKey returns the POPRF tweaked public key mG + pkS for the given info. It returns
+// ErrInvalidInput if the tweaked key is the point at infinity.
+func tweakedKey(pub, info []byte) ([]byte, error) {
+	m, err := tweak(info)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := scalarBaseMult(m)
+	if err != nil {
+		return nil, ErrInvalidInput
+	}
+
+	tweaked, err := addPoints(t, pub)
+	if err != nil {
+		return nil, ErrInvalidInput
+	}
+	return tweaked, nil
+}
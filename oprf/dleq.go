@@ -0,0 +1,136 @@
+package oprf
+
+import (
+	"crypto/sha256"
+	"io"
+)
+
+// generateProof returns a DLEQ proof that B = kA and D[i] = kC[i] for all i, as GenerateProof does
+// in RFC 9497, Section 2.2.1, reading the proof's random scalar from rand. A, B, C, and D are
+// compressed elements.
+func generateProof(mode Mode, rand io.Reader, k *scalar, a, b []byte, c, d [][]byte) ([]byte, error) {
+	m, err := composite(mode, b, c, d, c)
+	if err != nil {
+		return nil, err
+	}
+
+	z, err := scalarMult(m, k)
+	if err != nil {
+		return nil, err
+	}
+
+	var r scalar
+	if err := randomScalar(&r, rand); err != nil {
+		return nil, err
+	}
+
+	t2, err := scalarMult(a, &r)
+	if err != nil {
+		return nil, err
+	}
+
+	t3, err := scalarMult(m, &r)
+	if err != nil {
+		return nil, err
+	}
+
+	// s = r - ck
+	ch := challenge(mode, b, m, z, t2, t3)
+	var s scalar
+	s.Sub(&r, s.Mul(ch, k))
+	return append(ch.Bytes(), s.Bytes()...), nil
+}
+
+// verifyProof returns nil if proof is a valid DLEQ proof that B = kA and D[i] = kC[i] for all i, as
+// VerifyProof does in RFC 9497, Section 2.2.2, and ErrVerify otherwise.
+func verifyProof(mode Mode, a, b []byte, c, d [][]byte, proof []byte) error {
+	if len(proof) != ProofSize {
+		return ErrVerify
+	}
+
+	var ch, s scalar
+	if ch.SetCanonicalBytes((*[32]byte)(proof[:ScalarSize])) == 0 ||
+		s.SetCanonicalBytes((*[32]byte)(proof[ScalarSize:])) == 0 {
+		return ErrVerify
+	}
+
+	m, err := composite(mode, b, c, d, c)
+	if err != nil {
+		return ErrVerify
+	}
+
+	z, err := composite(mode, b, c, d, d)
+	if err != nil {
+		return ErrVerify
+	}
+
+	// t2 = sA + cB, t3 = sM + cZ
+	t2, err := linearCombination(a, &s, b, &ch)
+	if err != nil {
+		return ErrVerify
+	}
+
+	t3, err := linearCombination(m, &s, z, &ch)
+	if err != nil {
+		return ErrVerify
+	}
+
+	if challenge(mode, b, m, z, t2, t3).Equal(&ch) == 0 {
+		return ErrVerify
+	}
+	return nil
+}
+
+// composite returns Σ d_i * E[i], where E is either C or D and the d_i are derived from B, C, and
+// D, as in ComputeComposites from RFC 9497, Section 2.2.1. The prover computes the composite of D
+// as k times the composite of C instead, which is cheaper.
+func composite(mode Mode, b []byte, c, d, e [][]byte) ([]byte, error) {
+	h := sha256.New()
+	_, _ = h.Write(appendLengthPrefixed(nil, b))
+	_, _ = h.Write(appendLengthPrefixed(nil, append([]byte("Seed-"), contextString(mode)...)))
+	seed := h.Sum(nil)
+
+	var sum []byte
+	for i := range c {
+		t := appendLengthPrefixed(nil, seed)
+		t = append(t, byte(i>>8), byte(i))
+		t = appendLengthPrefixed(t, c[i])
+		t = appendLengthPrefixed(t, d[i])
+		t = append(t, "Composite"...)
+
+		p, err := scalarMult(e[i], hashToScalarDefault(mode, t))
+		if err != nil {
+			return nil, err
+		}
+
+		if sum == nil {
+			sum = p
+		} else if sum, err = addPoints(sum, p); err != nil {
+			return nil, err
+		}
+	}
+	return sum, nil
+}
+
+// challenge returns the DLEQ challenge scalar for the given elements.
+func challenge(mode Mode, b, m, z, t2, t3 []byte) *scalar {
+	var t []byte
+	for _, p := range [][]byte{b, m, z, t2, t3} {
+		t = appendLengthPrefixed(t, p)
+	}
+	return hashToScalarDefault(mode, append(t, "Challenge"...))
+}
+
+// linearCombination returns the compressed form of xP + yQ.
+func linearCombination(p []byte, x *scalar, q []byte, y *scalar) ([]byte, error) {
+	xp, err := scalarMult(p, x)
+	if err != nil {
+		return nil, err
+	}
+
+	yq, err := scalarMult(q, y)
+	if err != nil {
+		return nil, err
+	}
+	return addPoints(xp, yq)
+}
@@ -0,0 +1,108 @@
+package oprf
+
+import (
+	"crypto/subtle"
+	"io"
+	"math"
+)
+
+// PrivateKey is a Server's private key.
+type PrivateKey struct {
+	k   scalar
+	pub PublicKey
+}
+
+// GenerateKey returns a random private key, reading 32-byte candidates from rand until one is a
+// valid scalar. It returns ErrRandom if rand yields no valid scalar after many attempts.
+func GenerateKey(rand io.Reader) (*PrivateKey, error) {
+	var k scalar
+	if err := randomScalar(&k, rand); err != nil {
+		return nil, err
+	}
+	return newPrivateKey(&k)
+}
+
+// DeriveKey deterministically derives a private key for the given mode from a 32-byte seed and
+// info, as DeriveKeyPair does in RFC 9497, Section 3.2.1. It returns ErrInvalidSeed if seed is not
+// 32 bytes long, and ErrInvalidInput if info is longer than 65535 bytes.
+func DeriveKey(mode Mode, seed, info []byte) (*PrivateKey, error) {
+	if err := checkMode(mode, nil); err != nil {
+		return nil, err
+	}
+
+	if len(seed) != ScalarSize {
+		return nil, ErrInvalidSeed
+	}
+
+	if len(info) > math.MaxUint16 {
+		return nil, ErrInvalidInput
+	}
+
+	dst := append([]byte("DeriveKeyPair"), contextString(mode)...)
+	input := appendLengthPrefixed(append([]byte(nil), seed...), info)
+	for counter := range math.MaxUint8 + 1 {
+		k := hashToScalar(append(input, byte(counter)), dst)
+		if k.IsZero() == 0 {
+			return newPrivateKey(k)
+		}
+	}
+	return nil, ErrDeriveKeyPair
+}
+
+// NewPrivateKey returns the private key with the given 32-byte big-endian encoding. It returns
+// ErrInvalidKey if b is not a scalar in [1, n).
+func NewPrivateKey(b []byte) (*PrivateKey, error) {
+	var k scalar
+	if setScalarBytes(&k, b) == 0 {
+		return nil, ErrInvalidKey
+	}
+	return newPrivateKey(&k)
+}
+
+func newPrivateKey(k *scalar) (*PrivateKey, error) {
+	pk, err := scalarBaseMult(k)
+	if err != nil {
+		return nil, err
+	}
+	return &PrivateKey{k: *k, pub: PublicKey{b: pk}}, nil
+}
+
+// Bytes returns the 32-byte big-endian encoding of the private key.
+func (k *PrivateKey) Bytes() []byte {
+	return k.k.Bytes()
+}
+
+// PublicKey returns the public key corresponding to the private key.
+func (k *PrivateKey) PublicKey() *PublicKey {
+	return &k.pub
+}
+
+// Equal returns whether k and x are the same private key.
+func (k *PrivateKey) Equal(x *PrivateKey) bool {
+	return k.k.Equal(&x.k) == 1
+}
+
+// PublicKey is a Server's public key, which Clients use to verify proofs in the verifiable modes.
+type PublicKey struct {
+	b []byte
+}
+
+// NewPublicKey returns the public key with the given 33-byte compressed encoding. It returns
+// ErrInvalidKey if b is not a valid point.
+func NewPublicKey(b []byte) (*PublicKey, error) {
+	p, err := deserializeElement(b)
+	if err != nil {
+		return nil, ErrInvalidKey
+	}
+	return &PublicKey{b: p}, nil
+}
+
+// Bytes returns the 33-byte compressed encoding of the public key.
+func (k *PublicKey) Bytes() []byte {
+	return append([]byte(nil), k.b...)
+}
+
+// Equal returns whether k and x are the same public key.
+func (k *PublicKey) Equal(x *PublicKey) bool {
+	return subtle.ConstantTimeCompare(k.b, x.b) == 1
+}
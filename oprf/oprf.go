@@ -0,0 +1,266 @@
+// Package oprf implements the oblivious pseudorandom functions from RFC 9497 with the P256-SHA256
+// ciphersuite, in the base (OPRF), verifiable (VOPRF), and partially-oblivious (POPRF) modes.
+//
+// A Client blinds its inputs and sends the blinded elements to a Server, which evaluates them with
+// its private key and sends back the evaluated elements and, in the verifiable modes, a DLEQ proof
+// that they were evaluated with the key matching its public key. The Client then unblinds the
+// evaluated elements to produce the PRF outputs, without the Server learning the inputs or the
+// outputs.
+//
+// Elements are serialized as 33-byte compressed SEC points, as the RFC specifies. With WithElligator,
+// both sides instead send elements as 64-byte Elligator Squared representatives, which are
+// uniformly distributed over all 64-byte bitstrings, as elligator.EncodeUniform produces them.
+package oprf
+
+import (
+	"crypto/sha256"
+	"errors"
+	"io"
+	"math"
+
+	elligator "github.com/codahale/elligator-squared-p256"
+	"github.com/codahale/elligator-squared-p256/internal/p256"
+	"github.com/codahale/elligator-squared-p256/internal/xmd"
+)
+
+// Mode is an RFC 9497 protocol variant.
+type Mode byte
+
+const (
+	// ModeOPRF is the base mode, in which the Client cannot verify which key the Server used.
+	ModeOPRF Mode = 0x00
+	// ModeVOPRF is the verifiable mode, in which the Server proves it used the key matching its
+	// public key.
+	ModeVOPRF Mode = 0x01
+	// ModePOPRF is the partially-oblivious mode, which is verifiable and also binds each evaluation
+	// to public info known to both sides.
+	ModePOPRF Mode = 0x02
+)
+
+var (
+	// ErrInvalidMode is returned when a mode is not ModeOPRF, ModeVOPRF, or ModePOPRF.
+	ErrInvalidMode = errors.New("oprf: invalid mode")
+	// ErrInvalidKey is returned when a key is malformed, or when a verifiable mode is used without a
+	// public key.
+	ErrInvalidKey = errors.New("oprf: invalid key")
+	// ErrInvalidSeed is returned when a key derivation seed is not 32 bytes long.
+	ErrInvalidSeed = errors.New("oprf: invalid seed")
+	// ErrDeriveKeyPair is returned when no valid key can be derived from a seed.
+	ErrDeriveKeyPair = errors.New("oprf: key derivation failed")
+	// ErrInvalidInput is returned when an input or info string is longer than 65535 bytes, or when
+	// an input hashes to the point at infinity.
+	ErrInvalidInput = errors.New("oprf: invalid input")
+	// ErrInvalidElement is returned when a serialized element is malformed or not on the curve.
+	ErrInvalidElement = errors.New("oprf: invalid element")
+	// ErrInvalidBatch is returned when a batch is empty or its parts have different lengths.
+	ErrInvalidBatch = errors.New("oprf: invalid batch")
+	// ErrInvalidInfo is returned when info is given in a mode other than ModePOPRF.
+	ErrInvalidInfo = errors.New("oprf: info is only supported in POPRF mode")
+	// ErrInverse is returned in POPRF mode when the private key tweaked with the info is zero.
+	ErrInverse = errors.New("oprf: tweaked key is not invertible")
+	// ErrVerify is returned when a proof does not verify.
+	ErrVerify = errors.New("oprf: proof verification failed")
+	// ErrRandom is returned when a random source yields no valid scalar after many attempts, which
+	// is only plausible if it is broken.
+	ErrRandom = errors.New("oprf: random source yielded no valid scalar")
+)
+
+const (
+	// ElementSize is the size of a serialized element.
+	ElementSize = 33
+	// ElligatorElementSize is the size of an element serialized with WithElligator.
+	ElligatorElementSize = 64
+	// ScalarSize is the size of a serialized scalar, including private keys.
+	ScalarSize = 32
+	// ProofSize is the size of a serialized DLEQ proof.
+	ProofSize = 2 * ScalarSize
+	// OutputSize is the size of a PRF output.
+	OutputSize = sha256.Size
+)
+
+// Option configures a Client or Server.
+type Option func(*config)
+
+// WithElligator makes the Client send blinded elements, and the Server send evaluated elements, as
+// 64-byte Elligator Squared representatives instead of compressed points. Both sides must use it.
+// Proofs and outputs are unchanged.
+func WithElligator() Option {
+	return func(c *config) {
+		c.elligator = true
+	}
+}
+
+type config struct {
+	elligator bool
+}
+
+func newConfig(opts []Option) config {
+	var c config
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return c
+}
+
+// serialize returns the element p in the wire format c calls for, reading randomness for Elligator
+// Squared from rand.
+func (c *config) serialize(rand io.Reader, p []byte) ([]byte, error) {
+	if c.elligator {
+		return elligator.EncodeUniform(p, rand)
+	}
+	return p, nil
+}
+
+// deserialize returns the compressed form of the element b in the wire format c calls for.
+func (c *config) deserialize(b []byte) ([]byte, error) {
+	if c.elligator {
+		if len(b) != ElligatorElementSize {
+			return nil, ErrInvalidElement
+		}
+
+		p, err := elligator.DecodeCompressed(b)
+		if err != nil {
+			return nil, ErrInvalidElement
+		}
+		return p, nil
+	}
+	return deserializeElement(b)
+}
+
+// deserializeElement returns a copy of the compressed element b if it is a valid, non-identity
+// point, as DeserializeElement does in RFC 9497, Section 4.3.
+func deserializeElement(b []byte) ([]byte, error) {
+	var x, y p256.Element
+	if err := decompress(&x, &y, b); err != nil {
+		return nil, err
+	}
+	return append([]byte(nil), b...), nil
+}
+
+// decompress sets (x, y) to the compressed element b. It returns ErrInvalidElement if b is
+// malformed, the point at infinity, or not on the curve.
+func decompress(x, y *p256.Element, b []byte) error {
+	if len(b) != ElementSize || p256.Decompress(x, y, (*[ElementSize]byte)(b)) != 1 {
+		return ErrInvalidElement
+	}
+	return nil
+}
+
+// compress returns the compressed form of (x, y). It returns elligator.ErrIdentity if (x, y) is the
+// point at infinity.
+func compress(x, y *p256.Element) ([]byte, error) {
+	if x.IsZero()&y.IsZero() == 1 {
+		return nil, elligator.ErrIdentity
+	}
+	return p256.AppendCompressed(nil, x, y), nil
+}
+
+// generator returns the compressed form of the P-256 base point G.
+func generator() []byte {
+	var x, y p256.Element
+	p256.Generator(&x, &y)
+	return p256.AppendCompressed(nil, &x, &y)
+}
+
+// contextString returns the RFC 9497 context string for the given mode.
+func contextString(mode Mode) []byte {
+	return []byte("OPRFV1-" + string([]byte{byte(mode)}) + "-P256-SHA256")
+}
+
+// hashToGroup hashes msg to a compressed element with the mode's HashToGroup DST. It returns
+// ErrInvalidInput if msg hashes to the point at infinity.
+func hashToGroup(mode Mode, msg []byte) ([]byte, error) {
+	p, err := elligator.HashToCurve(msg, append([]byte("HashToGroup-"), contextString(mode)...))
+	if err != nil {
+		return nil, ErrInvalidInput
+	}
+	return append([]byte{2 | p[64]&1}, p[1:33]...), nil
+}
+
+// hashToScalar hashes msg to a scalar using hash_to_field from RFC 9380 with the given DST, as
+// HashToScalar does in RFC 9497, Section 4.3. dst must not be empty.
+func hashToScalar(msg, dst []byte) *scalar {
+	b := xmd.Expand48(msg, dst)
+	return new(scalar).SetWideBytes(&b)
+}
+
+// hashToScalarDefault hashes msg to a scalar with the mode's HashToScalar DST.
+func hashToScalarDefault(mode Mode, msg []byte) *scalar {
+	return hashToScalar(msg, append([]byte("HashToScalar-"), contextString(mode)...))
+}
+
+// scalarMult returns the compressed form of kP. It returns ErrInvalidElement if P is malformed, and
+// elligator.ErrIdentity if the result is the point at infinity.
+func scalarMult(p []byte, k *scalar) ([]byte, error) {
+	var x, y p256.Element
+	if err := decompress(&x, &y, p); err != nil {
+		return nil, err
+	}
+
+	p256.ScalarMult(&x, &y, &x, &y, k)
+	return compress(&x, &y)
+}
+
+// scalarBaseMult returns the compressed form of kG. It returns elligator.ErrIdentity if k is zero.
+func scalarBaseMult(k *scalar) ([]byte, error) {
+	var x, y p256.Element
+	p256.ScalarBaseMult(&x, &y, k)
+	return compress(&x, &y)
+}
+
+// addPoints returns the compressed form of P + Q. It returns ErrInvalidElement if either point is
+// malformed, and elligator.ErrIdentity if the result is the point at infinity.
+func addPoints(p, q []byte) ([]byte, error) {
+	var px, py, qx, qy p256.Element
+	if err := decompress(&px, &py, p); err != nil {
+		return nil, err
+	}
+
+	if err := decompress(&qx, &qy, q); err != nil {
+		return nil, err
+	}
+
+	p256.Add(&px, &py, &px, &py, &qx, &qy)
+	return compress(&px, &py)
+}
+
+// appendLengthPrefixed appends I2OSP(len(b), 2) || b to dst.
+func appendLengthPrefixed(dst, b []byte) []byte {
+	return append(append(dst, byte(len(b)>>8), byte(len(b))), b...)
+}
+
+// tweak returns the POPRF tweak m = HashToScalar("Info" || I2OSP(len(info), 2) || info).
+func tweak(info []byte) (*scalar, error) {
+	if len(info) > math.MaxUint16 {
+		return nil, ErrInvalidInput
+	}
+	return hashToScalarDefault(ModePOPRF, appendLengthPrefixed([]byte("Info"), info)), nil
+}
+
+// finalize returns the PRF output for the given input, info, and unblinded element, as the
+// Finalize functions do in RFC 9497, Section 3.3. Info is only hashed in POPRF mode.
+func finalize(mode Mode, input, info, unblinded []byte) []byte {
+	b := appendLengthPrefixed(nil, input)
+	if mode == ModePOPRF {
+		b = appendLengthPrefixed(b, info)
+	}
+	b = appendLengthPrefixed(b, unblinded)
+	h := sha256.Sum256(append(b, "Finalize"...))
+	return h[:]
+}
+
+// checkMode returns ErrInvalidMode if mode is unknown, and ErrInvalidInfo if info is given in a
+// mode other than ModePOPRF.
+func checkMode(mode Mode, info []byte) error {
+	switch mode {
+	case ModeOPRF, ModeVOPRF:
+		if info != nil {
+			return ErrInvalidInfo
+		}
+		return nil
+	case ModePOPRF:
+		return nil
+	default:
+		return ErrInvalidMode
+	}
+}
@@ -0,0 +1,552 @@
+package oprf
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func Example() {
+	// The server generates a key pair and publishes its public key.
+	key, err := GenerateKey(rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+
+	server, err := NewServer(ModeVOPRF, key, WithElligator())
+	if err != nil {
+		panic(err)
+	}
+
+	client, err := NewClient(ModeVOPRF, key.PublicKey(), WithElligator())
+	if err != nil {
+		panic(err)
+	}
+
+	// The client blinds its input and sends the blinded element to the server.
+	input := []byte("correct horse battery staple")
+	data, blinded, err := client.Blind(rand.Reader, [][]byte{input}, nil)
+	if err != nil {
+		panic(err)
+	}
+
+	// The server evaluates it and sends the evaluated element and proof back.
+	evaluated, proof, err := server.BlindEvaluate(rand.Reader, blinded, nil)
+	if err != nil {
+		panic(err)
+	}
+
+	// The client verifies the proof and unblinds the PRF output.
+	outputs, err := client.Finalize(data, evaluated, proof)
+	if err != nil {
+		panic(err)
+	}
+
+	want, err := server.Evaluate(input, nil)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(len(blinded[0]), len(evaluated[0]), bytes.Equal(outputs[0], want))
+	// Output:
+	// 64 64 true
+}
+
+type testVector struct {
+	input, info, blind, blinded, evaluated, output, proof, proofR string
+}
+
+func TestVectors(t *testing.T) {
+	t.Parallel()
+
+	// The P256-SHA256 vectors from RFC 9497, Appendix A.3. Batched values are comma-separated.
+	const (
+		seed    = "a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3"
+		keyInfo = "74657374206b6579"
+	)
+	var suites = []struct {
+		mode       Mode
+		skSm, pkSm string
+		vectors    []testVector
+	}{
+		{
+			mode: ModeOPRF,
+			skSm: "159749d750713afe245d2d39ccfaae8381c53ce92d098a9375ee70739c7ac0bf",
+			vectors: []testVector{
+				{
+					input:     "00",
+					blind:     "3338fa65ec36e0290022b48eb562889d89dbfa691d1cde91517fa222ed7ad364",
+					blinded:   "03723a1e5c09b8b9c18d1dcbca29e8007e95f14f4732d9346d490ffc195110368d",
+					evaluated: "030de02ffec47a1fd53efcdd1c6faf5bdc270912b8749e783c7ca75bb412958832",
+					output:    "a0b34de5fa4c5b6da07e72af73cc507cceeb48981b97b7285fc375345fe495dd",
+				},
+				{
+					input:     "5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a",
+					blind:     "3338fa65ec36e0290022b48eb562889d89dbfa691d1cde91517fa222ed7ad364",
+					blinded:   "03cc1df781f1c2240a64d1c297b3f3d16262ef5d4cf102734882675c26231b0838",
+					evaluated: "03a0395fe3828f2476ffcd1f4fe540e5a8489322d398be3c4e5a869db7fcb7c52c",
+					output:    "c748ca6dd327f0ce85f4ae3a8cd6d4d5390bbb804c9e12dcf94f853fece3dcce",
+				},
+			},
+		},
+		{
+			mode: ModeVOPRF,
+			skSm: "ca5d94c8807817669a51b196c34c1b7f8442fde4334a7121ae4736364312fca6",
+			pkSm: "03e17e70604bcabe198882c0a1f27a92441e774224ed9c702e51dd17038b102462",
+			vectors: []testVector{
+				{
+					input:     "00",
+					blind:     "3338fa65ec36e0290022b48eb562889d89dbfa691d1cde91517fa222ed7ad364",
+					blinded:   "02dd05901038bb31a6fae01828fd8d0e49e35a486b5c5d4b4994013648c01277da",
+					evaluated: "0209f33cab60cf8fe69239b0afbcfcd261af4c1c5632624f2e9ba29b90ae83e4a2",
+					output:    "0412e8f78b02c415ab3a288e228978376f99927767ff37c5718d420010a645a1",
+					proof:     "e7c2b3c5c954c035949f1f74e6bce2ed539a3be267d1481e9ddb178533df4c2664f69d065c604a4fd953e100b856ad83804eb3845189babfa5a702090d6fc5fa",
+					proofR:    "f9db001266677f62c095021db018cd8cbb55941d4073698ce45c405d1348b7b1",
+				},
+				{
+					input:     "5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a",
+					blind:     "3338fa65ec36e0290022b48eb562889d89dbfa691d1cde91517fa222ed7ad364",
+					blinded:   "03cd0f033e791c4d79dfa9c6ed750f2ac009ec46cd4195ca6fd3800d1e9b887dbd",
+					evaluated: "030d2985865c693bf7af47ba4d3a3813176576383d19aff003ef7b0784a0d83cf1",
+					output:    "771e10dcd6bcd3664e23b8f2a710cfaaa8357747c4a8cbba03133967b5c24f18",
+					proof:     "2787d729c57e3d9512d3aa9e8708ad226bc48e0f1750b0767aaff73482c44b8d2873d74ec88aebd3504961acea16790a05c542d9fbff4fe269a77510db00abab",
+					proofR:    "f9db001266677f62c095021db018cd8cbb55941d4073698ce45c405d1348b7b1",
+				},
+				{
+					input:     "00,5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a",
+					blind:     "3338fa65ec36e0290022b48eb562889d89dbfa691d1cde91517fa222ed7ad364,f9db001266677f62c095021db018cd8cbb55941d4073698ce45c405d1348b7b1",
+					blinded:   "02dd05901038bb31a6fae01828fd8d0e49e35a486b5c5d4b4994013648c01277da,03462e9ae64cae5b83ba98a6b360d942266389ac369b923eb3d557213b1922f8ab",
+					evaluated: "0209f33cab60cf8fe69239b0afbcfcd261af4c1c5632624f2e9ba29b90ae83e4a2,02bb24f4d838414aef052a8f044a6771230ca69c0a5677540fff738dd31bb69771",
+					output:    "0412e8f78b02c415ab3a288e228978376f99927767ff37c5718d420010a645a1,771e10dcd6bcd3664e23b8f2a710cfaaa8357747c4a8cbba03133967b5c24f18",
+					proof:     "bdcc351707d02a72ce49511c7db990566d29d6153ad6f8982fad2b435d6ce4d60da1e6b3fa740811bde34dd4fe0aa1b5fe6600d0440c9ddee95ea7fad7a60cf2",
+					proofR:    "350e8040f828bf6ceca27405420cdf3d63cb3aef005f40ba51943c8026877963",
+				},
+			},
+		},
+		{
+			mode: ModePOPRF,
+			skSm: "6ad2173efa689ef2c27772566ad7ff6e2d59b3b196f00219451fb2c89ee4dae2",
+			pkSm: "030d7ff077fddeec965db14b794f0cc1ba9019b04a2f4fcc1fa525dedf72e2a3e3",
+			vectors: []testVector{
+				{
+					input:     "00",
+					info:      "7465737420696e666f",
+					blind:     "3338fa65ec36e0290022b48eb562889d89dbfa691d1cde91517fa222ed7ad364",
+					blinded:   "031563e127099a8f61ed51eeede05d747a8da2be329b40ba1f0db0b2bd9dd4e2c0",
+					evaluated: "02c5e5300c2d9e6ba7f3f4ad60500ad93a0157e6288eb04b67e125db024a2c74d2",
+					output:    "193a92520bd8fd1f37accb918040a57108daa110dc4f659abe212636d245c592",
+					proof:     "f8a33690b87736c854eadfcaab58a59b8d9c03b569110b6f31f8bf7577f3fbb85a8a0c38468ccde1ba942be501654adb106167c8eb178703ccb42bccffb9231a",
+					proofR:    "f9db001266677f62c095021db018cd8cbb55941d4073698ce45c405d1348b7b1",
+				},
+				{
+					input:     "5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a",
+					info:      "7465737420696e666f",
+					blind:     "3338fa65ec36e0290022b48eb562889d89dbfa691d1cde91517fa222ed7ad364",
+					blinded:   "021a440ace8ca667f261c10ac7686adc66a12be31e3520fca317643a1eee9dcd4d",
+					evaluated: "0208ca109cbae44f4774fc0bdd2783efdcb868cb4523d52196f700210e777c5de3",
+					output:    "1e6d164cfd835d88a31401623549bf6b9b306628ef03a7962921d62bc5ffce8c",
+					proof:     "043a8fb7fc7fd31e35770cabda4753c5bf0ecc1e88c68d7d35a62bf2631e875af4613641be2d1875c31d1319d191c4bbc0d04875f4fd03c31d3d17dd8e069b69",
+					proofR:    "f9db001266677f62c095021db018cd8cbb55941d4073698ce45c405d1348b7b1",
+				},
+				{
+					input:     "00,5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a",
+					info:      "7465737420696e666f",
+					blind:     "3338fa65ec36e0290022b48eb562889d89dbfa691d1cde91517fa222ed7ad364,f9db001266677f62c095021db018cd8cbb55941d4073698ce45c405d1348b7b1",
+					blinded:   "031563e127099a8f61ed51eeede05d747a8da2be329b40ba1f0db0b2bd9dd4e2c0,03ca4ff41c12fadd7a0bc92cf856732b21df652e01a3abdf0fa8847da053db213c",
+					evaluated: "02c5e5300c2d9e6ba7f3f4ad60500ad93a0157e6288eb04b67e125db024a2c74d2,02f0b6bcd467343a8d8555a99dc2eed0215c71898c5edb77a3d97ddd0dbad478e8",
+					output:    "193a92520bd8fd1f37accb918040a57108daa110dc4f659abe212636d245c592,1e6d164cfd835d88a31401623549bf6b9b306628ef03a7962921d62bc5ffce8c",
+					proof:     "8fbd85a32c13aba79db4b42e762c00687d6dbf9c8cb97b2a225645ccb00d9d7580b383c885cdfd07df448d55e06f50f6173405eee5506c0ed0851ff718d13e68",
+					proofR:    "350e8040f828bf6ceca27405420cdf3d63cb3aef005f40ba51943c8026877963",
+				},
+			},
+		},
+	}
+	for _, suite := range suites {
+		key, err := DeriveKey(suite.mode, mustHex(t, seed), mustHex(t, keyInfo))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got, want := hex.EncodeToString(key.Bytes()), suite.skSm; got != want {
+			t.Errorf("DeriveKey(mode=%d) = %s, want = %s", suite.mode, got, want)
+		}
+
+		if got, want := hex.EncodeToString(key.PublicKey().Bytes()), suite.pkSm; want != "" && got != want {
+			t.Errorf("PublicKey(mode=%d) = %s, want = %s", suite.mode, got, want)
+		}
+
+		for i, v := range suite.vectors {
+			t.Run(fmt.Sprintf("mode=%d/%d", suite.mode, i), func(t *testing.T) {
+				t.Parallel()
+
+				testVectorRoundTrip(t, suite.mode, key, &v)
+			})
+		}
+	}
+}
+
+func testVectorRoundTrip(t *testing.T, mode Mode, key *PrivateKey, v *testVector) {
+	t.Helper()
+
+	client, err := NewClient(mode, key.PublicKey())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server, err := NewServer(mode, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var info []byte
+	if v.info != "" {
+		info = mustHex(t, v.info)
+	}
+
+	inputs := mustHexList(t, v.input)
+	blinds := bytes.NewReader(bytes.Join(mustHexList(t, v.blind), nil))
+	data, blinded, err := client.Blind(blinds, inputs, info)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := hexList(blinded), v.blinded; got != want {
+		t.Errorf("Blind() = %s, want = %s", got, want)
+	}
+
+	var proofR []byte
+	if v.proofR != "" {
+		proofR = mustHex(t, v.proofR)
+	}
+
+	evaluated, proof, err := server.BlindEvaluate(bytes.NewReader(proofR), blinded, info)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := hexList(evaluated), v.evaluated; got != want {
+		t.Errorf("BlindEvaluate() = %s, want = %s", got, want)
+	}
+
+	if got, want := hex.EncodeToString(proof), v.proof; got != want {
+		t.Errorf("BlindEvaluate() proof = %s, want = %s", got, want)
+	}
+
+	outputs, err := client.Finalize(data, evaluated, proof)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := hexList(outputs), v.output; got != want {
+		t.Errorf("Finalize() = %s, want = %s", got, want)
+	}
+
+	for i, input := range inputs {
+		output, err := server.Evaluate(input, info)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(output, outputs[i]) {
+			t.Errorf("Evaluate(%x) = %x, want = %x", input, output, outputs[i])
+		}
+	}
+}
+
+func TestElligator(t *testing.T) {
+	t.Parallel()
+
+	for _, mode := range []Mode{ModeOPRF, ModeVOPRF, ModePOPRF} {
+		t.Run(fmt.Sprintf("mode=%d", mode), func(t *testing.T) {
+			t.Parallel()
+
+			key, err := GenerateKey(rand.Reader)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			client, server := newPair(t, mode, key, WithElligator())
+
+			var info []byte
+			if mode == ModePOPRF {
+				info = []byte("info")
+			}
+
+			inputs := [][]byte{[]byte("one"), []byte("two"), []byte("three")}
+			data, blinded, err := client.Blind(rand.Reader, inputs, info)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			evaluated, proof, err := server.BlindEvaluate(rand.Reader, blinded, info)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, b := range append(blinded, evaluated...) {
+				if len(b) != ElligatorElementSize {
+					t.Errorf("len(%x) = %d, want = %d", b, len(b), ElligatorElementSize)
+				}
+			}
+
+			outputs, err := client.Finalize(data, evaluated, proof)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for i, input := range inputs {
+				want, err := server.Evaluate(input, info)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if !bytes.Equal(outputs[i], want) {
+					t.Errorf("Finalize(%q) = %x, want = %x", input, outputs[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestFinalizeWrongKey(t *testing.T) {
+	t.Parallel()
+
+	for _, mode := range []Mode{ModeVOPRF, ModePOPRF} {
+		t.Run(fmt.Sprintf("mode=%d", mode), func(t *testing.T) {
+			t.Parallel()
+
+			key, err := GenerateKey(rand.Reader)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			other, err := GenerateKey(rand.Reader)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			client, _ := newPair(t, mode, other)
+			_, server := newPair(t, mode, key)
+
+			data, blinded, err := client.Blind(rand.Reader, [][]byte{[]byte("input")}, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			evaluated, proof, err := server.BlindEvaluate(rand.Reader, blinded, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := client.Finalize(data, evaluated, proof); !errors.Is(err, ErrVerify) {
+				t.Errorf("Finalize() err = %v, want = %v", err, ErrVerify)
+			}
+		})
+	}
+}
+
+func TestFinalizeTamperedProof(t *testing.T) {
+	t.Parallel()
+
+	key, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client, server := newPair(t, ModeVOPRF, key)
+	data, blinded, err := client.Blind(rand.Reader, [][]byte{[]byte("input")}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	evaluated, proof, err := server.BlindEvaluate(rand.Reader, blinded, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := range proof {
+		tampered := bytes.Clone(proof)
+		tampered[i] ^= 1
+		if _, err := client.Finalize(data, evaluated, tampered); !errors.Is(err, ErrVerify) {
+			t.Errorf("Finalize() with proof[%d] flipped err = %v, want = %v", i, err, ErrVerify)
+		}
+	}
+
+	if _, err := client.Finalize(data, evaluated, proof[:ProofSize-1]); !errors.Is(err, ErrVerify) {
+		t.Errorf("Finalize() with short proof err = %v, want = %v", err, ErrVerify)
+	}
+}
+
+func TestInvalid(t *testing.T) {
+	t.Parallel()
+
+	key, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client, server := newPair(t, ModeOPRF, key)
+	invalid := append([]byte{0x02}, bytes.Repeat([]byte{0xff}, 32)...)
+
+	for _, tc := range []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"NewClient(mode=3)", second(NewClient(3, nil)), ErrInvalidMode},
+		{"NewClient(nil key)", second(NewClient(ModeVOPRF, nil)), ErrInvalidKey},
+		{"NewServer(nil key)", second(NewServer(ModeOPRF, nil)), ErrInvalidKey},
+		{"NewPrivateKey(zero)", second(NewPrivateKey(make([]byte, 32))), ErrInvalidKey},
+		{"NewPublicKey(invalid)", second(NewPublicKey(invalid)), ErrInvalidKey},
+		{"DeriveKey(short seed)", second(DeriveKey(ModeOPRF, make([]byte, 31), nil)), ErrInvalidSeed},
+		{"Blind(info)", third(client.Blind(rand.Reader, [][]byte{{}}, []byte{})), ErrInvalidInfo},
+		{"Blind(empty)", third(client.Blind(rand.Reader, nil, nil)), ErrInvalidBatch},
+		{"Blind(long input)", third(client.Blind(rand.Reader, [][]byte{make([]byte, 1<<16)}, nil)), ErrInvalidInput},
+		{"BlindEvaluate(invalid)", third(server.BlindEvaluate(rand.Reader, [][]byte{invalid}, nil)), ErrInvalidElement},
+		{"BlindEvaluate(short)", third(server.BlindEvaluate(rand.Reader, [][]byte{invalid[:32]}, nil)), ErrInvalidElement},
+		{"BlindEvaluate(empty)", third(server.BlindEvaluate(rand.Reader, nil, nil)), ErrInvalidBatch},
+		{"Evaluate(info)", second(server.Evaluate(nil, []byte{})), ErrInvalidInfo},
+	} {
+		if !errors.Is(tc.err, tc.want) {
+			t.Errorf("%s err = %v, want = %v", tc.name, tc.err, tc.want)
+		}
+	}
+
+	data, _, err := client.Blind(rand.Reader, [][]byte{[]byte("input")}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.Finalize(data, nil, nil); !errors.Is(err, ErrInvalidBatch) {
+		t.Errorf("Finalize(nil) err = %v, want = %v", err, ErrInvalidBatch)
+	}
+
+	if _, err := client.Finalize(data, [][]byte{invalid}, nil); !errors.Is(err, ErrInvalidElement) {
+		t.Errorf("Finalize(invalid) err = %v, want = %v", err, ErrInvalidElement)
+	}
+}
+
+func TestBrokenRandom(t *testing.T) {
+	t.Parallel()
+
+	key, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client, server := newPair(t, ModeVOPRF, key)
+	_, blinded, err := client.Blind(rand.Reader, [][]byte{[]byte("input")}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Neither zero nor all ones (which is at least n) is a valid scalar, so a reader which only
+	// returns one of them never yields one.
+	for _, b := range []byte{0x00, 0xff} {
+		r := constantReader(b)
+		for _, tc := range []struct {
+			name string
+			err  error
+		}{
+			{"GenerateKey", second(GenerateKey(r))},
+			{"Blind", third(client.Blind(r, [][]byte{[]byte("input")}, nil))},
+			{"BlindEvaluate", third(server.BlindEvaluate(r, blinded, nil))},
+		} {
+			if !errors.Is(tc.err, ErrRandom) {
+				t.Errorf("%s(%#02x reader) err = %v, want = %v", tc.name, b, tc.err, ErrRandom)
+			}
+		}
+	}
+}
+
+func TestKeyRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	key, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sk, err := NewPrivateKey(key.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !sk.Equal(key) {
+		t.Errorf("NewPrivateKey(%x) = %x", key.Bytes(), sk.Bytes())
+	}
+
+	pk, err := NewPublicKey(key.PublicKey().Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !pk.Equal(key.PublicKey()) {
+		t.Errorf("NewPublicKey(%x) = %x", key.PublicKey().Bytes(), pk.Bytes())
+	}
+}
+
+func newPair(t *testing.T, mode Mode, key *PrivateKey, opts ...Option) (*Client, *Server) {
+	t.Helper()
+
+	client, err := NewClient(mode, key.PublicKey(), opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server, err := NewServer(mode, key, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client, server
+}
+
+// constantReader is an io.Reader which only returns its value.
+type constantReader byte
+
+func (r constantReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = byte(r)
+	}
+	return len(p), nil
+}
+
+func second[T any](_ T, err error) error {
+	return err
+}
+
+func third[T, U any](_ T, _ U, err error) error {
+	return err
+}
+
+func mustHex(t *testing.T, s string) []byte {
+	t.Helper()
+
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func mustHexList(t *testing.T, s string) [][]byte {
+	t.Helper()
+
+	var out [][]byte
+	for _, v := range strings.Split(s, ",") {
+		out = append(out, mustHex(t, v))
+	}
+	return out
+}
+
+func hexList(bs [][]byte) string {
+	s := make([]string, len(bs))
+	for i, b := range bs {
+		s[i] = hex.EncodeToString(b)
+	}
+	return strings.Join(s, ",")
+}
@@ -0,0 +1,41 @@
+package oprf
+
+import (
+	"io"
+
+	"github.com/codahale/elligator-squared-p256/internal/p256"
+)
+
+// scalar is an integer modulo the order of P-256.
+type scalar = p256.Scalar
+
+// maxRandomAttempts is the number of 32-byte candidates randomScalar reads before giving up. Each
+// candidate is rejected with probability less than 2^-32, so exhausting it is only plausible with a
+// broken RNG.
+const maxRandomAttempts = 64
+
+// randomScalar sets s to a uniformly random non-zero scalar, reading 32 bytes at a time from rand
+// and rejecting values which are not in [1, n). It returns ErrRandom if none of maxRandomAttempts
+// candidates is valid. It runs in variable time.
+func randomScalar(s *scalar, rand io.Reader) error {
+	var b [ScalarSize]byte
+	for range maxRandomAttempts {
+		if _, err := io.ReadFull(rand, b[:]); err != nil {
+			return err
+		}
+
+		if setScalarBytes(s, b[:]) == 1 {
+			return nil
+		}
+	}
+	return ErrRandom
+}
+
+// setScalarBytes sets s to the 32-byte big-endian value b, and returns 1 if b was in [1, n) and 0
+// otherwise.
+func setScalarBytes(s *scalar, b []byte) int {
+	if len(b) != ScalarSize {
+		return 0
+	}
+	return s.SetCanonicalBytes((*[ScalarSize]byte)(b)) & (1 ^ s.IsZero())
+}
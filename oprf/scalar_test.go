@@ -0,0 +1,61 @@
+package oprf
+
+import (
+	"bytes"
+	"crypto/elliptic"
+	"crypto/rand"
+	"math/big"
+	"testing"
+)
+
+func TestSetScalarBytes(t *testing.T) {
+	t.Parallel()
+
+	n := elliptic.P256().Params().N
+	for _, tc := range []struct {
+		name string
+		b    []byte
+		ok   int
+	}{
+		{"zero", make([]byte, 32), 0},
+		{"one", big.NewInt(1).FillBytes(make([]byte, 32)), 1},
+		{"n-1", new(big.Int).Sub(n, big.NewInt(1)).FillBytes(make([]byte, 32)), 1},
+		{"n", n.FillBytes(make([]byte, 32)), 0},
+		{"max", bytes.Repeat([]byte{0xff}, 32), 0},
+		{"short", make([]byte, 31), 0},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var s scalar
+			if got := setScalarBytes(&s, tc.b); got != tc.ok {
+				t.Fatalf("setScalarBytes(%x) = %d, want = %d", tc.b, got, tc.ok)
+			}
+
+			if tc.ok == 1 && !bytes.Equal(s.Bytes(), tc.b) {
+				t.Errorf("Bytes() = %x, want = %x", s.Bytes(), tc.b)
+			}
+		})
+	}
+}
+
+func TestRandomScalar(t *testing.T) {
+	t.Parallel()
+
+	// The first candidate is n, which is rejected, and the second is 1.
+	candidates := append(elliptic.P256().Params().N.FillBytes(make([]byte, 32)), make([]byte, 31)...)
+	candidates = append(candidates, 1)
+
+	var s scalar
+	if err := randomScalar(&s, bytes.NewReader(candidates)); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := s.Bytes(), big.NewInt(1).FillBytes(make([]byte, 32)); !bytes.Equal(got, want) {
+		t.Errorf("randomScalar() = %x, want = %x", got, want)
+	}
+
+	if err := randomScalar(&s, rand.Reader); err != nil || s.IsZero() == 1 {
+		t.Errorf("randomScalar() = %s, %v, want a non-zero scalar", &s, err)
+	}
+}
@@ -0,0 +1,160 @@
+package oprf
+
+import (
+	"io"
+	"math"
+)
+
+// Server evaluates blinded elements with its private key.
+type Server struct {
+	mode Mode
+	key  *PrivateKey
+	config
+}
+
+// NewServer returns a Server for the given mode and private key.
+func NewServer(mode Mode, key *PrivateKey, opts ...Option) (*Server, error) {
+	if err := checkMode(mode, nil); err != nil {
+		return nil, err
+	}
+
+	if key == nil {
+		return nil, ErrInvalidKey
+	}
+	return &Server{mode: mode, key: key, config: newConfig(opts)}, nil
+}
+
+// BlindEvaluate evaluates the Client's blinded elements and returns the evaluated elements along
+// with a 64-byte DLEQ proof covering all of them in ModeVOPRF and ModePOPRF, or a nil proof in
+// ModeOPRF. It reads the proof's random scalar, and the randomness for WithElligator, from rand.
+// Info is the public POPRF info, and must be nil in the other modes.
+//
+// BlindEvaluate returns ErrInvalidBatch if there are no blinded elements, ErrInvalidElement if one
+// is malformed, ErrInvalidInput if info is longer than 65535 bytes, ErrInvalidInfo if info is
+// given outside of ModePOPRF, ErrInverse if the private key tweaked with info is zero, and ErrRandom
+// if rand yields no valid proof scalar after many attempts.
+func (s *Server) BlindEvaluate(rand io.Reader, blinded [][]byte, info []byte) ([][]byte, []byte, error) {
+	if err := checkMode(s.mode, info); err != nil {
+		return nil, nil, err
+	}
+
+	if len(blinded) == 0 {
+		return nil, nil, ErrInvalidBatch
+	}
+
+	elems := make([][]byte, len(blinded))
+	for i, b := range blinded {
+		var err error
+		if elems[i], err = s.deserialize(b); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	k, err := s.evaluationKey(info)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	evaluated := make([][]byte, len(elems))
+	for i, p := range elems {
+		if evaluated[i], err = scalarMult(p, k); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	proof, err := s.prove(rand, info, elems, evaluated)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for i, p := range evaluated {
+		if evaluated[i], err = s.serialize(rand, p); err != nil {
+			return nil, nil, err
+		}
+	}
+	return evaluated, proof, nil
+}
+
+// Evaluate returns the PRF output for the given input directly, without blinding, as the Client
+// would compute it. Info is the public POPRF info, and must be nil in the other modes.
+//
+// Evaluate returns ErrInvalidInput if the input or info is longer than 65535 bytes or the input
+// hashes to the point at infinity, ErrInvalidInfo if info is given outside of ModePOPRF, and
+// ErrInverse if the private key tweaked with info is zero.
+func (s *Server) Evaluate(input, info []byte) ([]byte, error) {
+	if err := checkMode(s.mode, info); err != nil {
+		return nil, err
+	}
+
+	if len(input) > math.MaxUint16 {
+		return nil, ErrInvalidInput
+	}
+
+	p, err := hashToGroup(s.mode, input)
+	if err != nil {
+		return nil, err
+	}
+
+	k, err := s.evaluationKey(info)
+	if err != nil {
+		return nil, err
+	}
+
+	evaluated, err := scalarMult(p, k)
+	if err != nil {
+		return nil, err
+	}
+	return finalize(s.mode, input, info, evaluated), nil
+}
+
+// evaluationKey returns the scalar elements are multiplied by: the private key k in ModeOPRF and
+// ModeVOPRF, and 1/(k + m) in ModePOPRF, where m is the tweak derived from info.
+func (s *Server) evaluationKey(info []byte) (*scalar, error) {
+	if s.mode != ModePOPRF {
+		return &s.key.k, nil
+	}
+
+	t, err := s.tweakedScalar(info)
+	if err != nil {
+		return nil, err
+	}
+	return t.Invert(t), nil
+}
+
+// tweakedScalar returns k + m, where m is the tweak derived from info. It returns ErrInverse if the
+// sum is zero.
+func (s *Server) tweakedScalar(info []byte) (*scalar, error) {
+	m, err := tweak(info)
+	if err != nil {
+		return nil, err
+	}
+
+	t := new(scalar).Add(&s.key.k, m)
+	if t.IsZero() == 1 {
+		return nil, ErrInverse
+	}
+	return t, nil
+}
+
+// prove returns the DLEQ proof for the mode, or nil in ModeOPRF.
+func (s *Server) prove(rand io.Reader, info []byte, blinded, evaluated [][]byte) ([]byte, error) {
+	switch s.mode {
+	case ModeOPRF:
+		return nil, nil
+	case ModeVOPRF:
+		return generateProof(s.mode, rand, &s.key.k, generator(), s.key.pub.b, blinded, evaluated)
+	case ModePOPRF:
+		t, err := s.tweakedScalar(info)
+		if err != nil {
+			return nil, err
+		}
+
+		tweaked, err := scalarBaseMult(t)
+		if err != nil {
+			return nil, err
+		}
+		return generateProof(s.mode, rand, t, generator(), tweaked, evaluated, blinded)
+	default:
+		return nil, ErrInvalidMode
+	}
+}
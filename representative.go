@@ -0,0 +1,85 @@
+package elligator
+
+import (
+	"database/sql/driver"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+)
+
+// Representative is an Elligator Squared encoding of a P-256 point.
+//
+// Its binary form is the raw 64 bytes, and its text and JSON forms are unpadded base64url. It can
+// be stored in and scanned from SQL databases as a 64-byte blob.
+type Representative [64]byte
+
+// Point maps the representative to an uncompressed SEC-encoded point.
+func (r Representative) Point() ([]byte, error) {
+	return Decode(r[:])
+}
+
+func (r Representative) String() string {
+	return base64.RawURLEncoding.EncodeToString(r[:])
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (r Representative) MarshalBinary() ([]byte, error) {
+	return r[:], nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. It returns the same errors as Decode for
+// invalid representatives.
+func (r *Representative) UnmarshalBinary(data []byte) error {
+	var x, y fieldElement
+	if err := decodeBytes(&x, &y, data); err != nil {
+		return err
+	}
+	copy(r[:], data)
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (r Representative) MarshalText() ([]byte, error) {
+	return base64.RawURLEncoding.AppendEncode(nil, r[:]), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. It returns the same errors as Decode for
+// invalid representatives.
+func (r *Representative) UnmarshalText(text []byte) error {
+	b, err := base64.RawURLEncoding.AppendDecode(nil, text)
+	if err != nil {
+		return ErrInvalidEncoding
+	}
+	return r.UnmarshalBinary(b)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (r Representative) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (r *Representative) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return r.UnmarshalText([]byte(s))
+}
+
+// Value implements driver.Valuer.
+func (r Representative) Value() (driver.Value, error) {
+	return r[:], nil
+}
+
+// Scan implements sql.Scanner. It accepts both the binary and text forms of a representative.
+func (r *Representative) Scan(src any) error {
+	switch src := src.(type) {
+	case []byte:
+		return r.UnmarshalBinary(src)
+	case string:
+		return r.UnmarshalText([]byte(src))
+	default:
+		return fmt.Errorf("elligator: cannot scan %T into Representative", src)
+	}
+}
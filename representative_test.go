@@ -0,0 +1,214 @@
+package elligator
+
+import (
+	"bytes"
+	"crypto/ecdh"
+	"crypto/rand"
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+var (
+	_ encoding.BinaryMarshaler   = Representative{}
+	_ encoding.BinaryUnmarshaler = (*Representative)(nil)
+	_ encoding.TextMarshaler     = Representative{}
+	_ encoding.TextUnmarshaler   = (*Representative)(nil)
+	_ json.Marshaler             = Representative{}
+	_ json.Unmarshaler           = (*Representative)(nil)
+	_ driver.Valuer              = Representative{}
+	_ sql.Scanner                = (*Representative)(nil)
+)
+
+func TestRepresentativeMarshaling(t *testing.T) {
+	t.Parallel()
+
+	k, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	encoded, err := Encode(k.PublicKey().Bytes(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var r Representative
+	if err := r.UnmarshalBinary(encoded); err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := r.Point()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := p, k.PublicKey().Bytes(); !bytes.Equal(got, want) {
+		t.Fatalf("Point() = %x, want = %x", got, want)
+	}
+
+	t.Run("binary", func(t *testing.T) {
+		t.Parallel()
+
+		b, err := r.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var r2 Representative
+		if err := r2.UnmarshalBinary(b); err != nil {
+			t.Fatal(err)
+		}
+
+		if r2 != r {
+			t.Errorf("UnmarshalBinary(%x) = %x, want = %x", b, r2, r)
+		}
+	})
+
+	t.Run("text", func(t *testing.T) {
+		t.Parallel()
+
+		b, err := r.MarshalText()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got, want := len(b), 86; got != want {
+			t.Errorf("len(MarshalText()) = %d, want = %d", got, want)
+		}
+
+		if got, want := string(b), r.String(); got != want {
+			t.Errorf("MarshalText() = %s, want = %s", got, want)
+		}
+
+		var r2 Representative
+		if err := r2.UnmarshalText(b); err != nil {
+			t.Fatal(err)
+		}
+
+		if r2 != r {
+			t.Errorf("UnmarshalText(%s) = %x, want = %x", b, r2, r)
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		t.Parallel()
+
+		type message struct {
+			Key Representative `json:"key"`
+		}
+
+		b, err := json.Marshal(message{Key: r})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got, want := string(b), `{"key":"`+r.String()+`"}`; got != want {
+			t.Errorf("json.Marshal() = %s, want = %s", got, want)
+		}
+
+		var m message
+		if err := json.Unmarshal(b, &m); err != nil {
+			t.Fatal(err)
+		}
+
+		if m.Key != r {
+			t.Errorf("json.Unmarshal(%s) = %x, want = %x", b, m.Key, r)
+		}
+	})
+
+	t.Run("sql", func(t *testing.T) {
+		t.Parallel()
+
+		v, err := r.Value()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, src := range []any{v, r.String()} {
+			var r2 Representative
+			if err := r2.Scan(src); err != nil {
+				t.Fatal(err)
+			}
+
+			if r2 != r {
+				t.Errorf("Scan(%v) = %x, want = %x", src, r2, r)
+			}
+		}
+	})
+}
+
+func TestRepresentativeInvalid(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		name string
+		f    func(r *Representative) error
+		err  error
+	}{
+		{
+			name: "binary short",
+			f:    func(r *Representative) error { return r.UnmarshalBinary(make([]byte, 63)) },
+			err:  ErrInvalidEncoding,
+		},
+		{
+			name: "binary long",
+			f:    func(r *Representative) error { return r.UnmarshalBinary(make([]byte, 65)) },
+			err:  ErrInvalidEncoding,
+		},
+		{
+			name: "binary identity",
+			f:    func(r *Representative) error { return r.UnmarshalBinary(make([]byte, 64)) },
+			err:  ErrIdentity,
+		},
+		{
+			name: "text short",
+			f:    func(r *Representative) error { return r.UnmarshalText([]byte(strings.Repeat("A", 84))) },
+			err:  ErrInvalidEncoding,
+		},
+		{
+			name: "text malformed",
+			f:    func(r *Representative) error { return r.UnmarshalText([]byte(strings.Repeat("*", 86))) },
+			err:  ErrInvalidEncoding,
+		},
+		{
+			name: "json identity",
+			f: func(r *Representative) error {
+				return r.UnmarshalJSON([]byte(`"` + strings.Repeat("A", 86) + `"`))
+			},
+			err: ErrIdentity,
+		},
+		{
+			name: "scan short",
+			f:    func(r *Representative) error { return r.Scan([]byte{1, 2, 3}) },
+			err:  ErrInvalidEncoding,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var r Representative
+			if err := test.f(&r); !errors.Is(err, test.err) {
+				t.Errorf("err = %v, want = %v", err, test.err)
+			}
+
+			if r != (Representative{}) {
+				t.Errorf("r = %x, want = zero", r)
+			}
+		})
+	}
+}
+
+func TestRepresentativeScanUnsupported(t *testing.T) {
+	t.Parallel()
+
+	var r Representative
+	if err := r.Scan(int64(1)); err == nil {
+		t.Error("Scan(int64) err = nil, want error")
+	}
+}
@@ -0,0 +1,91 @@
+package elligator
+
+import "github.com/codahale/elligator-squared-p256/internal/p256"
+
+// sampleCandidate is a variable-time alternative to candidate which implements the sampling
+// algorithm from the Elligator Squared paper (Tibouchi, https://eprint.iacr.org/2014/043, Algorithm
+// 1): it computes every preimage of q = p - f(u) under f at once, accepts u with probability t/4,
+// where t is the number of preimages, and sets v to one of them chosen uniformly at random. j is a
+// uniformly random value in [0, 4), which selects the preimage or rejects u if j >= t.
+//
+// Each u is accepted with the same probability, and each preimage chosen with the same probability,
+// as with candidate, so the output has the same distribution. The difference is in the cost of
+// rejection: if q has no preimages because the first square root in r does not exist, which is the
+// case for half of all u, sampleCandidate returns without computing the divisor's inversion or the
+// second square roots. Because it branches on the number of preimages, it leaks how many
+// candidates were rejected and at which step, which depends on the point being encoded.
+func sampleCandidate(v, px, py, u *fieldElement, j byte) int {
+	// Reject random field elements \in {-1, 0, 1}.
+	if isExceptional(u) == 1 {
+		return 0
+	}
+
+	// q = p - f(u), rejecting -p.
+	var x, y fieldElement
+	f(&x, &y, u)
+	y.Neg(&y)
+	p256.Add(&x, &y, px, py, &x, &y)
+	if x.IsZero()&y.IsZero() == 1 {
+		return 0
+	}
+
+	// Accept with probability t/4, picking one of the t preimages uniformly.
+	var vs [4]fieldElement
+	if t := preimages(&vs, &x, &y); int(j) >= t {
+		return 0
+	}
+	v.Set(&vs[j])
+	return 1
+}
+
+// preimages sets vs[:t] to the t distinct preimages of the point (x, y) under f, in the order of
+// the j values r finds them for, and returns t. (x, y) must not be the point at infinity. It runs in
+// variable time.
+func preimages(vs *[4]fieldElement, x, y *fieldElement) int {
+	// All four preimages share omega and the first square root, so if it does not exist there are
+	// none.
+	var omega, a, bInv fieldElement
+	if rOmega(&omega, &a, x) != 1 {
+		return 0
+	}
+
+	// They also share the divisor, so it is only inverted once.
+	rSelect(&a, &bInv, &omega, y, 0)
+	bInv.Invert(&bInv)
+
+	t := 0
+	for _, negA := range [2]bool{false, true} {
+		// c = (omega ± a) / b, whose square roots are the preimages for j = 2 * negA + {0, 1}.
+		var c fieldElement
+		if negA {
+			c.Sub(&omega, &a)
+		} else {
+			c.Add(&omega, &a)
+		}
+		c.Mul(&c, &bInv)
+		if c.SqrtCandidate(&c) != 1 {
+			continue
+		}
+
+		// Skip any repeated roots, which happen when either square root is of zero.
+		var negC fieldElement
+		negC.Neg(&c)
+		for _, e := range [2]*fieldElement{&c, &negC} {
+			if !containsElement(vs[:t], e) {
+				vs[t].Set(e)
+				t++
+			}
+		}
+	}
+	return t
+}
+
+// containsElement returns whether e is in es. It runs in variable time.
+func containsElement(es []fieldElement, e *fieldElement) bool {
+	for i := range es {
+		if es[i].Equal(e) == 1 {
+			return true
+		}
+	}
+	return false
+}
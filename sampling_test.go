@@ -0,0 +1,185 @@
+package elligator
+
+import (
+	"bytes"
+	"crypto/ecdh"
+	"crypto/rand"
+	"crypto/sha3"
+	"slices"
+	"testing"
+)
+
+func BenchmarkEncodeAllPreimages(b *testing.B) {
+	for _, bench := range []struct {
+		name string
+		opts []EncoderOption
+	}{
+		{name: "default"},
+		{name: "all preimages", opts: []EncoderOption{WithAllPreimages()}},
+	} {
+		b.Run(bench.name, func(b *testing.B) {
+			// Use CSHAKE128 as a deterministic source of "random" data to allow for deterministic
+			// benchmarking. The number of candidates varies a lot between points, so cycle through
+			// many of them.
+			prng := sha3.NewCSHAKE128([]byte("elligator-squared-p256-benchmark"), nil)
+			points := make([][]byte, 256)
+			for i := range points {
+				k, err := ecdh.P256().GenerateKey(prng)
+				if err != nil {
+					b.Fatal(err)
+				}
+				points[i] = k.PublicKey().Bytes()
+			}
+
+			e := NewEncoder(append(bench.opts, WithRand(prng))...)
+			i := 0
+			for b.Loop() {
+				if _, err := e.Encode(points[i%len(points)]); err != nil {
+					b.Fatal(err)
+				}
+				i++
+			}
+		})
+	}
+}
+
+func BenchmarkSampleCandidate(b *testing.B) {
+	prng := sha3.NewCSHAKE128([]byte("elligator-squared-p256-benchmark"), nil)
+	k, err := ecdh.P256().GenerateKey(prng)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	var px, py fieldElement
+	if err := p256SetBytes(&px, &py, k.PublicKey().Bytes(), AllFormats); err != nil {
+		b.Fatal(err)
+	}
+
+	us := make([]fieldElement, 256)
+	for i := range us {
+		var buf [32]byte
+		_, _ = prng.Read(buf[:])
+		us[i].SetBytes(&buf)
+	}
+
+	for _, bench := range []struct {
+		name string
+		f    func(v, px, py, u *fieldElement, j byte) int
+	}{
+		{name: "candidate", f: candidate},
+		{name: "sampleCandidate", f: sampleCandidate},
+	} {
+		b.Run(bench.name, func(b *testing.B) {
+			var v fieldElement
+			i := 0
+			for b.Loop() {
+				bench.f(&v, &px, &py, &us[i%len(us)], byte(i))
+				i++
+			}
+		})
+	}
+}
+
+func TestEncodeAllPreimages(t *testing.T) {
+	t.Parallel()
+
+	for _, e := range []*Encoder{
+		NewEncoder(WithAllPreimages()),
+		NewEncoder(WithAllPreimages(), WithUniform()),
+	} {
+		for range 100 {
+			k, err := ecdh.P256().GenerateKey(rand.Reader)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			encoded, err := e.Encode(k.PublicKey().Bytes())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			q, err := Decode(encoded)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got, want := q, k.PublicKey().Bytes(); !bytes.Equal(got, want) {
+				t.Fatalf("Decode(%x) = %x, want = %x", encoded, got, want)
+			}
+		}
+	}
+}
+
+func TestSampleCandidate(t *testing.T) {
+	t.Parallel()
+
+	k, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var px, py fieldElement
+	if err := p256SetBytes(&px, &py, k.PublicKey().Bytes(), AllFormats); err != nil {
+		t.Fatal(err)
+	}
+
+	// For every u, sampleCandidate accepts the same set of representatives over all j as candidate
+	// does, each for exactly one j, so the two have the same output distribution.
+	for range 500 {
+		var b [32]byte
+		if _, err := rand.Read(b[:]); err != nil {
+			t.Fatal(err)
+		}
+
+		var u fieldElement
+		u.SetBytes(&b)
+
+		var got, want []string
+		for j := range byte(4) {
+			var v fieldElement
+			if sampleCandidate(&v, &px, &py, &u, j) == 1 {
+				got = append(got, v.String())
+			}
+
+			if candidate(&v, &px, &py, &u, j) == 1 {
+				want = append(want, v.String())
+			}
+		}
+		slices.Sort(got)
+		slices.Sort(want)
+
+		if !slices.Equal(got, want) {
+			t.Errorf("sampleCandidate(u=%x) accepts %v, want = %v", b, got, want)
+		}
+	}
+}
+
+func TestPreimagesInvertF(t *testing.T) {
+	t.Parallel()
+
+	// Every point in the image of f has a preimage, and f maps each one back to the point.
+	for range 500 {
+		var b [32]byte
+		if _, err := rand.Read(b[:]); err != nil {
+			t.Fatal(err)
+		}
+
+		var u, x, y fieldElement
+		u.SetBytes(&b)
+		f(&x, &y, &u)
+
+		var vs [4]fieldElement
+		n := preimages(&vs, &x, &y)
+		if n < 1 {
+			t.Fatalf("preimages(f(%x)) = 0, want at least 1", b)
+		}
+
+		for _, v := range vs[:n] {
+			var fx, fy fieldElement
+			f(&fx, &fy, &v)
+			if fx.Equal(&x)&fy.Equal(&y) != 1 {
+				t.Errorf("f(preimages(f(%x))) = (%s, %s), want = (%s, %s)", b, &fx, &fy, &x, &y)
+			}
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package elligator
+
+import (
+	"context"
+	"io"
+
+	"github.com/codahale/elligator-squared-p256/internal/p256"
+)
+
+// EncodeSSWU maps the given SEC-encoded point to a random 64-byte bitstring, as with Encode, but
+// using the Simplified Shallue-van de Woestijne-Ulas map from RFC 9380, Section 6.6.2, with the
+// P-256 constant Z = -10, in place of f.
+//
+// The two halves u and v of the encoding are big-endian field elements for which
+// map_to_curve_simple_swu(u) + map_to_curve_simple_swu(v) is the point, so representatives can be
+// checked with any RFC 9380 implementation. They must be decoded with DecodeSSWU, not Decode.
+func EncodeSSWU(p []byte, rand io.Reader) ([]byte, error) {
+	e := Encoder{rand: rand, attempts: DefaultMaxAttempts, sswu: true, formats: AllFormats}
+	return e.appendEncode(context.Background(), nil, p)
+}
+
+// DecodeSSWU maps a 64-byte bitstring produced by EncodeSSWU to an uncompressed SEC-encoded point.
+// It returns ErrInvalidEncoding if b is not 64 bytes long, and ErrIdentity if b decodes to the
+// point at infinity.
+func DecodeSSWU(b []byte) ([]byte, error) {
+	if len(b) != 64 {
+		return nil, ErrInvalidEncoding
+	}
+
+	var u, v, x, y, x2, y2 fieldElement
+	u.SetBytes((*[32]byte)(b[:32]))
+	v.SetBytes((*[32]byte)(b[32:]))
+	sswu(&x, &y, &u)
+	sswu(&x2, &y2, &v)
+	p256.Add(&x, &y, &x, &y, &x2, &y2)
+	if x.IsZero()&y.IsZero() == 1 {
+		return nil, ErrIdentity
+	}
+	return appendUncompressed(nil, &x, &y), nil
+}
+
+// sswuCandidate is like candidate, but for map_to_curve_simple_swu. It runs in constant time.
+func sswuCandidate(v, px, py, u *fieldElement, j byte) int {
+	// q = p - sswu(u). sswu never returns the point at infinity, but q may be it.
+	var x, y fieldElement
+	sswu(&x, &y, u)
+	y.Neg(&y)
+	p256.Add(&x, &y, px, py, &x, &y)
+	ok := 1 ^ (x.IsZero() & y.IsZero())
+
+	return ok & sswuInverse(v, &x, &y, j)
+}
+
+// sswu sets (x, y) to map_to_curve_simple_swu(u) from RFC 9380, Section 6.6.2. It runs in constant
+// time. The outputs may overlap with u.
+func sswu(x, y, u *fieldElement) {
+	// tv1 = Z u^2, and x1 = -B/A (1 + 1/(tv1^2 + tv1)), or B/(ZA) if the denominator is zero.
+	var tv1, tv2, x1, exceptional fieldElement
+	tv1.Square(u)
+	tv1.Mul(&tv1, new(fieldElement).SetInt64(-10))
+	tv2.Square(&tv1)
+	tv2.Add(&tv2, &tv1)
+	tv2.Invert(&tv2)
+	isExceptional := tv2.IsZero()
+	x1.Add(&tv2, new(fieldElement).One())
+	x1.Mul(&x1, new(fieldElement).SetNegBOverA())
+	x1.Select(exceptional.SetBOverZA(), &x1, isExceptional)
+
+	// If g(x1) is square, use (x1, \sqrt{g(x1)}); otherwise, use (x2, \sqrt{g(x2)}), where
+	// x2 = Z u^2 x1.
+	var x2, y1, y2 fieldElement
+	x2.Mul(&tv1, &x1)
+	isSquare := y1.SqrtCandidate(p256.G(&y1, &x1))
+	y2.SqrtCandidate(p256.G(&y2, &x2))
+
+	// Fix the sign of y to match the sign of u.
+	sgnU := sgn0(u)
+	x.Select(&x1, &x2, isSquare)
+	y.Select(&y1, &y2, isSquare)
+	var negY fieldElement
+	negY.Neg(y)
+	y.Select(y, &negY, sgnU^sgn0(y)^1)
+}
+
+// sswuInverse sets e to the jth preimage of (x, y) under sswu, and returns 1 if that preimage exists
+// and 0 otherwise. It runs in constant time. e may overlap with x or y.
+//
+// With k = (A/B) x + 1 and t = Z u^2, a preimage from the x1 branch satisfies t^2 + t + 1/k = 0 and
+// one from the x2 branch satisfies t^2 + kt + k = 0. Both have the discriminant k^2 - 4k up to a
+// square factor, giving t = (-k ± \sqrt{k^2 - 4k}) / 2, divided by k for the x1 branch. Bit 1 of j
+// selects the branch, and bit 0 selects the sign of the square root. The sign of u is fixed by the
+// sign of y, so each t gives at most one preimage.
+func sswuInverse(e, x, y *fieldElement, j byte) int {
+	var k, m, negM fieldElement
+	k.SetAOverB()
+	k.Mul(&k, x)
+	k.Add(&k, new(fieldElement).One())
+
+	// m = \pm\sqrt{k^2 - 4k}
+	var fourK fieldElement
+	m.Square(&k)
+	fourK.Add(&k, &k)
+	fourK.Add(&fourK, &fourK)
+	m.Sub(&m, &fourK)
+	ok := m.SqrtCandidate(&m)
+	negM.Neg(&m)
+	m.Select(&negM, &m, int(j)&1)
+
+	// u^2 = t/Z = (m - k) / 2Z, divided by k for the x1 branch.
+	var u2, kInv fieldElement
+	u2.Sub(&m, &k)
+	u2.Mul(&u2, new(fieldElement).SetInvTwoZ())
+	kInv.Invert(&k)
+	kInv.Select(new(fieldElement).One(), &kInv, int(j>>1)&1)
+	u2.Mul(&u2, &kInv)
+
+	var u, negU fieldElement
+	ok &= u.SqrtCandidate(&u2)
+	negU.Neg(&u)
+	u.Select(&negU, &u, sgn0(&u)^sgn0(y))
+
+	// The preimage is only valid if sswu takes the same branch for it, which also rules out the
+	// exceptional cases.
+	var x2, y2 fieldElement
+	sswu(&x2, &y2, &u)
+	ok &= x2.Equal(x) & y2.Equal(y)
+
+	e.Set(&u)
+	return ok
+}
+
+// sgn0 returns the sign of e as defined in RFC 9380, Section 4.1, which is its parity.
+func sgn0(e *fieldElement) int {
+	var b [32]byte
+	e.FillBytes(&b)
+	return int(b[31] & 1)
+}
@@ -0,0 +1,108 @@
+package elligator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// RecordError is returned by a StreamDecoder when it reads a malformed record. It wraps the
+// underlying error: io.ErrUnexpectedEOF for a truncated record, or the error Decode returned.
+type RecordError struct {
+	// Offset is the byte offset of the start of the malformed record in the stream.
+	Offset int64
+
+	// Err is the underlying error.
+	Err error
+}
+
+func (e *RecordError) Error() string {
+	return fmt.Sprintf("elligator: malformed record at offset %d: %v", e.Offset, e.Err)
+}
+
+func (e *RecordError) Unwrap() error {
+	return e.Err
+}
+
+// A StreamEncoder writes a stream of Elligator Squared-encoded points to an io.Writer. The stream
+// consists of back-to-back 64-byte representatives with no headers or framing, so it is
+// indistinguishable from random data.
+type StreamEncoder struct {
+	w   io.Writer
+	e   Encoder
+	buf []byte
+}
+
+// NewStreamEncoder returns a StreamEncoder which writes to w, using rand to encode points.
+func NewStreamEncoder(w io.Writer, rand io.Reader) *StreamEncoder {
+	return &StreamEncoder{
+		w:   w,
+		e:   Encoder{rand: rand, attempts: DefaultMaxAttempts, formats: AllFormats},
+		buf: make([]byte, 0, 64),
+	}
+}
+
+// WritePoint encodes the given SEC-encoded point, as with Encode, and writes its 64-byte
+// representative to the underlying writer. If the writer accepts fewer than 64 bytes without
+// returning an error, WritePoint returns io.ErrShortWrite.
+func (s *StreamEncoder) WritePoint(p []byte) error {
+	b, err := s.e.appendEncode(context.Background(), s.buf[:0], p)
+	if err != nil {
+		return err
+	}
+	defer clear(b)
+
+	n, err := s.w.Write(b)
+	if err != nil {
+		return err
+	}
+
+	if n != len(b) {
+		return io.ErrShortWrite
+	}
+	return nil
+}
+
+// A StreamDecoder reads a stream of Elligator Squared-encoded points written by a StreamEncoder
+// from an io.Reader.
+type StreamDecoder struct {
+	r   io.Reader
+	off int64
+	err error
+	buf [64]byte
+}
+
+// NewStreamDecoder returns a StreamDecoder which reads from r.
+func NewStreamDecoder(r io.Reader) *StreamDecoder {
+	return &StreamDecoder{r: r}
+}
+
+// ReadPoint reads the next 64-byte representative from the underlying reader and returns the
+// uncompressed SEC encoding of its point. Short reads are retried until a full record is read.
+//
+// At the end of the stream, ReadPoint returns io.EOF. If the stream ends partway through a record,
+// or a record does not decode to a valid point, ReadPoint returns a *RecordError with the offset of
+// that record. Once ReadPoint returns an error, it returns the same error for all subsequent calls.
+func (s *StreamDecoder) ReadPoint() ([]byte, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	if _, err := io.ReadFull(s.r, s.buf[:]); err != nil {
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			err = &RecordError{Offset: s.off, Err: err}
+		}
+		s.err = err
+		return nil, err
+	}
+
+	p, err := Decode(s.buf[:])
+	if err != nil {
+		s.err = &RecordError{Offset: s.off, Err: err}
+		return nil, s.err
+	}
+
+	s.off += int64(len(s.buf))
+	return p, nil
+}
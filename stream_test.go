@@ -0,0 +1,131 @@
+package elligator
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"io"
+	"testing"
+	"testing/iotest"
+)
+
+func TestStreamRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	points := bulkTestPoints(t, 20)
+
+	var buf bytes.Buffer
+	e := NewStreamEncoder(&buf, rand.Reader)
+	for _, p := range points {
+		if err := e.WritePoint(p); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if got, want := buf.Len(), len(points)*64; got != want {
+		t.Fatalf("stream length = %d, want = %d", got, want)
+	}
+
+	// Read the stream back a byte at a time to exercise short reads.
+	d := NewStreamDecoder(iotest.OneByteReader(&buf))
+	for i, want := range points {
+		got, err := d.ReadPoint()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(got, want) {
+			t.Errorf("ReadPoint() #%d = %x, want = %x", i, got, want)
+		}
+	}
+
+	if _, err := d.ReadPoint(); !errors.Is(err, io.EOF) {
+		t.Errorf("ReadPoint() = %v, want = %v", err, io.EOF)
+	}
+}
+
+func TestStreamEncoderInvalidPoint(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	if err := NewStreamEncoder(&buf, rand.Reader).WritePoint([]byte{0}); !errors.Is(err, ErrIdentity) {
+		t.Errorf("WritePoint() = %v, want = %v", err, ErrIdentity)
+	}
+
+	if buf.Len() != 0 {
+		t.Errorf("stream length = %d, want = 0", buf.Len())
+	}
+}
+
+func TestStreamEncoderShortWrite(t *testing.T) {
+	t.Parallel()
+
+	e := NewStreamEncoder(&shortWriter{}, rand.Reader)
+	if err := e.WritePoint(bulkTestPoints(t, 1)[0]); !errors.Is(err, io.ErrShortWrite) {
+		t.Errorf("WritePoint() = %v, want = %v", err, io.ErrShortWrite)
+	}
+}
+
+func TestStreamDecoderMalformed(t *testing.T) {
+	t.Parallel()
+
+	valid, err := Encode(bulkTestPoints(t, 1)[0], rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = []struct {
+		name   string
+		stream []byte
+		offset int64
+		err    error
+	}{
+		{
+			name:   "truncated",
+			stream: bytes.Join([][]byte{valid, valid, valid[:10]}, nil),
+			offset: 128,
+			err:    io.ErrUnexpectedEOF,
+		},
+		{
+			name:   "identity",
+			stream: bytes.Join([][]byte{valid, make([]byte, 64), valid}, nil),
+			offset: 64,
+			err:    ErrIdentity,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			d := NewStreamDecoder(bytes.NewReader(test.stream))
+			var err error
+			for err == nil {
+				_, err = d.ReadPoint()
+			}
+
+			var recordErr *RecordError
+			if !errors.As(err, &recordErr) {
+				t.Fatalf("ReadPoint() = %v, want = %T", err, recordErr)
+			}
+
+			if got, want := recordErr.Offset, test.offset; got != want {
+				t.Errorf("Offset = %d, want = %d", got, want)
+			}
+
+			if !errors.Is(err, test.err) {
+				t.Errorf("ReadPoint() = %v, want = %v", err, test.err)
+			}
+
+			// Errors are sticky.
+			if _, again := d.ReadPoint(); again != err {
+				t.Errorf("ReadPoint() = %v, want = %v", again, err)
+			}
+		})
+	}
+}
+
+type shortWriter struct{}
+
+func (*shortWriter) Write(p []byte) (int, error) {
+	return len(p) / 2, nil
+}